@@ -0,0 +1,46 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"os"
+	"strings"
+)
+
+// APIKeyHeader carries the service API key when in-service key checks are enabled
+const APIKeyHeader = "X-Api-Key"
+
+// loadAPIKeys reads the accepted API keys from SCRAPE_API_KEYS (comma-separated, so old
+// and new keys both work during rotation), falling back to the single SCRAPE_API_KEY.
+// No keys means authentication is left to API Gateway.
+func loadAPIKeys() []string {
+	raw := os.Getenv("SCRAPE_API_KEYS")
+	if raw == "" {
+		raw = os.Getenv("SCRAPE_API_KEY")
+	}
+
+	var keys []string
+	for _, key := range strings.Split(raw, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// validAPIKey reports whether the provided key matches any accepted key. Keys are compared
+// as SHA-256 digests in constant time, since ConstantTimeCompare returns early on a length
+// mismatch, and every key is checked so the match position isn't observable either.
+func validAPIKey(provided string, keys []string) bool {
+	if provided == "" {
+		return false
+	}
+
+	providedDigest := sha256.Sum256([]byte(provided))
+	match := 0
+	for _, key := range keys {
+		keyDigest := sha256.Sum256([]byte(key))
+		match |= subtle.ConstantTimeCompare(providedDigest[:], keyDigest[:])
+	}
+	return match == 1
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLoadAPIKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   string
+		single string
+		want   []string
+	}{
+		{"rotation list", "old-key, new-key", "", []string{"old-key", "new-key"}},
+		{"list wins over single key", "new-key", "legacy-key", []string{"new-key"}},
+		{"single key fallback", "", "legacy-key", []string{"legacy-key"}},
+		{"blank entries dropped", "old-key,, ,new-key,", "", []string{"old-key", "new-key"}},
+		{"none", "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SCRAPE_API_KEYS", tt.keys)
+			t.Setenv("SCRAPE_API_KEY", tt.single)
+
+			got := loadAPIKeys()
+			if len(got) != len(tt.want) {
+				t.Fatalf("loadAPIKeys() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("loadAPIKeys() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidAPIKeyRotation(t *testing.T) {
+	keys := []string{"old-key", "new-key"}
+
+	tests := []struct {
+		name     string
+		provided string
+		want     bool
+	}{
+		{"old key", "old-key", true},
+		{"new key", "new-key", true},
+		{"wrong key", "other-key", false},
+		{"empty key", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validAPIKey(tt.provided, keys); got != tt.want {
+				t.Errorf("validAPIKey(%q) = %v, want %v", tt.provided, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		provided string
+		keys     []string
+		want     bool
+	}{
+		{"exact match", "s3cret-key", []string{"s3cret-key"}, true},
+		{"shorter key", "s3cret", []string{"s3cret-key"}, false},
+		{"longer key", "s3cret-key-2", []string{"s3cret-key"}, false},
+		{"same length, wrong key", "s3cret-kez", []string{"s3cret-key"}, false},
+		{"case differs", "S3CRET-KEY", []string{"s3cret-key"}, false},
+		{"no keys configured", "s3cret-key", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validAPIKey(tt.provided, tt.keys); got != tt.want {
+				t.Errorf("validAPIKey(%q) = %v, want %v", tt.provided, got, tt.want)
+			}
+		})
+	}
+}
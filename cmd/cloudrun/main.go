@@ -1,13 +1,18 @@
 // Package main provides the Google Cloud Run HTTP handler for the web scraper service.
 // It handles incoming requests, performs web scraping operations,
 // and returns structured JSON responses with extracted article content.
-// Authentication is handled by API Gateway before requests reach this service.
+// Authentication is handled by API Gateway before requests reach this service,
+// optionally backed by an in-service X-Api-Key check.
 package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -22,21 +27,32 @@ import (
 // CloudRunHandler handles Google Cloud Run requests
 type CloudRunHandler struct {
 	scraper *scraper.Scraper
+	apiKeys []string
 }
 
 func NewCloudRunHandler() *CloudRunHandler {
 	return &CloudRunHandler{
 		scraper: scraper.NewScraper(),
+		apiKeys: loadAPIKeys(),
 	}
 }
 
 // Handler is the main Cloud Run handler function
 func (h *CloudRunHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	// Correlate every log line and the response with a request ID
+	requestID := r.Header.Get(RequestIDHeader)
+	if !validRequestID(requestID) {
+		requestID = newRequestID()
+	}
+	logger := slog.Default().With("request_id", requestID)
+
 	// Set up CORS headers
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set(RequestIDHeader, requestID)
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Access-Control-Allow-Methods", "GET,OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, If-None-Match, If-Modified-Since, "+RequestIDHeader+", "+APIKeyHeader)
+	w.Header().Set("Access-Control-Expose-Headers", RequestIDHeader+", Server-Timing, "+FinalURLHeader+", "+UpstreamStatusHeader)
+	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,OPTIONS")
 
 	// Handle preflight OPTIONS request
 	if r.Method == "OPTIONS" {
@@ -44,31 +60,47 @@ func (h *CloudRunHandler) Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Only allow GET requests
-	if r.Method != "GET" {
-		h.errorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+	// Only allow GET requests, and POST for options sent in the body
+	if r.Method != "GET" && r.Method != "POST" {
+		h.errorResponse(w, http.StatusMethodNotAllowed, models.ErrCodeMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	// Log the request
-	fmt.Printf("Request received: %s %s\n", r.Method, r.URL.String())
+	logger.Info("request received", "method", r.Method, "path", r.URL.Path)
 
-	// API key validation is now handled by API Gateway
+	// API Gateway validates keys; the service checks X-Api-Key only when keys are configured
+	if len(h.apiKeys) > 0 && !validAPIKey(r.Header.Get(APIKeyHeader), h.apiKeys) {
+		logger.Warn("request completed", "status", http.StatusUnauthorized, "outcome", models.ErrCodeUnauthorized)
+		h.errorResponse(w, http.StatusUnauthorized, models.ErrCodeUnauthorized, "Invalid or missing API key")
+		return
+	}
 
 	// Validate URL parameter
 	targetURL := r.URL.Query().Get("url")
 	if targetURL == "" {
-		h.errorResponse(w, http.StatusBadRequest, "Missing \"url\" query parameter")
+		h.errorResponse(w, http.StatusBadRequest, models.ErrCodeMissingURL, "Missing \"url\" query parameter")
 		return
 	}
 
 	// Validate URL format
 	if _, err := url.Parse(targetURL); err != nil {
-		h.errorResponse(w, http.StatusBadRequest, "Invalid URL format")
+		h.errorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidURL, "Invalid URL format")
 		return
 	}
 
-	fmt.Printf("Starting scrape for: %s\n", targetURL)
+	// A POST body keeps headers such as cookies out of URLs and access logs
+	var body requestBody
+	if r.Method == "POST" {
+		var err error
+		if body, err = readRequestBody(w, r); err != nil {
+			h.errorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidBody, "Invalid JSON body")
+			return
+		}
+	}
+
+	logger = logger.With("url", targetURL)
+	logger.Info("starting scrape")
 
 	// Calculate timeout (Cloud Run has 5 minute max)
 	timeoutStr := r.URL.Query().Get("timeout")
@@ -90,19 +122,48 @@ func (h *CloudRunHandler) Handler(w http.ResponseWriter, r *http.Request) {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(timeoutMs)*time.Millisecond)
 	defer cancel()
+	ctx = scraper.WithLogger(ctx, logger)
 
 	start := time.Now()
 
+	// Reachability check only, no extraction
+	if queryBool(r.URL.Query(), "validate", false) {
+		h.validateResponse(ctx, w, targetURL, start, logger)
+		return
+	}
+
+	// Feed and sitemap discovery only, no extraction
+	if queryBool(r.URL.Query(), "discover", false) {
+		options := scraper.DiscoverOptions{ExpandSitemaps: queryBool(r.URL.Query(), "expandSitemaps", false)}
+		h.discoverResponse(ctx, w, targetURL, options, start, logger)
+		return
+	}
+
+	// Parse per-request extraction options
+	options := parseExtractionOptions(r.URL.Query())
+	options.Headers = mergeHeaders(options.Headers, body.Headers)
+
+	// Forward cache validators so unchanged upstream pages short-circuit with 304
+	options.IfNoneMatch = r.Header.Get("If-None-Match")
+	options.IfModifiedSince = r.Header.Get("If-Modified-Since")
+
+	// Fetched HTML as-is, for debugging extractions and clients with their own parser
+	if queryBool(r.URL.Query(), "raw", false) {
+		h.rawResponse(ctx, w, targetURL, options, start, logger)
+		return
+	}
+
 	// Perform scraping
-	result, err := h.scraper.ScrapeSmartWithTimeout(ctx, targetURL, timeoutMs)
+	result, err := h.scraper.ScrapeSmartWithOptions(ctx, targetURL, options)
 
 	duration := time.Since(start)
-	fmt.Printf("✓ Scraped in %dms\n", duration.Milliseconds())
+	logger = logger.With("duration_ms", duration.Milliseconds())
 
 	// Handle Cloudflare blocking
 	if cfErr, ok := err.(*models.CloudflareBlockError); ok {
 		blockedResponse := models.BlockedResponse{
 			Error:    "Blocked by site protection",
+			Code:     models.ErrCodeBlocked,
 			Provider: "cloudflare",
 			Domain:   cfErr.Domain,
 			Metadata: models.Metadata{
@@ -112,21 +173,66 @@ func (h *CloudRunHandler) Handler(w http.ResponseWriter, r *http.Request) {
 			},
 		}
 
+		logger.Warn("request completed", "status", http.StatusUnavailableForLegalReasons, "outcome", models.ErrCodeBlocked)
 		w.WriteHeader(http.StatusUnavailableForLegalReasons)
 		json.NewEncoder(w).Encode(blockedResponse)
 		return
 	}
 
+	// Handle http:// targets refused by the HTTPS mode
+	if errors.Is(err, scraper.ErrHTTPSRequired) {
+		logger.Warn("request completed", "status", http.StatusBadRequest, "outcome", models.ErrCodeInvalidURL, "error", err)
+		h.errorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidURL, "Only https:// URLs are accepted")
+		return
+	}
+
+	// Handle redirects to a login form, which would otherwise extract as a poor article
+	var loginErr *models.LoginRequiredError
+	if errors.As(err, &loginErr) {
+		logger.Warn("request completed", "status", http.StatusForbidden, "outcome", models.ErrCodeLoginRequired, "error", err)
+		h.errorResponse(w, http.StatusForbidden, models.ErrCodeLoginRequired, "Page requires a login")
+		return
+	}
+
+	// Handle unchanged upstream content
+	if errors.Is(err, scraper.ErrNotModified) {
+		if result.Metadata.ETag != "" {
+			w.Header().Set("ETag", result.Metadata.ETag)
+		}
+		if result.Metadata.LastModified != "" {
+			w.Header().Set("Last-Modified", result.Metadata.LastModified)
+		}
+		logger.Info("request completed", "status", http.StatusNotModified, "outcome", "not_modified")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// Handle scanned/image-only PDFs
+	if errors.Is(err, scraper.ErrPDFNoText) {
+		logger.Warn("request completed", "status", http.StatusUnprocessableEntity, "outcome", models.ErrCodeUnextractable, "error", err)
+		h.errorResponse(w, http.StatusUnprocessableEntity, models.ErrCodeUnextractable, "PDF has no extractable text")
+		return
+	}
+
+	// Handle pages that parsed to nothing extractable, like JSON served as text/html
+	var extractErr *models.ContentExtractionError
+	if errors.As(err, &extractErr) {
+		logger.Warn("request completed", "status", http.StatusUnprocessableEntity, "outcome", models.ErrCodeUnextractable, "error", err)
+		h.errorResponse(w, http.StatusUnprocessableEntity, models.ErrCodeUnextractable, "Page has no extractable HTML content")
+		return
+	}
+
 	// Handle timeout
 	if err != nil && strings.Contains(err.Error(), "context deadline exceeded") {
-		h.errorResponse(w, http.StatusGatewayTimeout, "Scrape took too long")
+		logger.Warn("request completed", "status", http.StatusGatewayTimeout, "outcome", models.ErrCodeTimeout, "error", err)
+		h.errorResponse(w, http.StatusGatewayTimeout, models.ErrCodeTimeout, "Scrape took too long")
 		return
 	}
 
 	// Handle other errors
 	if err != nil {
-		fmt.Printf("Error processing request: %v\n", err)
-		h.errorResponse(w, http.StatusInternalServerError, "Failed to scrape")
+		logger.Error("request completed", "status", http.StatusInternalServerError, "outcome", models.ErrCodeUpstreamError, "error", err)
+		h.errorResponse(w, http.StatusInternalServerError, models.ErrCodeUpstreamError, "Failed to scrape")
 		return
 	}
 
@@ -135,23 +241,190 @@ func (h *CloudRunHandler) Handler(w http.ResponseWriter, r *http.Request) {
 	result.Metadata.ScrapedAt = time.Now()
 	result.Metadata.DurationMs = duration.Milliseconds()
 
+	if timing := result.Metadata.Timing; timing != nil {
+		w.Header().Set("Server-Timing", serverTiming(timing, result.Metadata.DurationMs))
+	}
+
+	logger.Info("request completed", "status", http.StatusOK, "outcome", "success",
+		"word_count", result.Quality.WordCount)
+
 	// Return successful response
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(result)
 }
 
+// validateResponse runs a reachability check and writes its result
+func (h *CloudRunHandler) validateResponse(ctx context.Context, w http.ResponseWriter, targetURL string, start time.Time, logger *slog.Logger) {
+	result, err := h.scraper.Validate(ctx, targetURL)
+	duration := time.Since(start)
+	logger = logger.With("duration_ms", duration.Milliseconds())
+
+	if errors.Is(err, scraper.ErrHTTPSRequired) {
+		logger.Warn("validate completed", "status", http.StatusBadRequest, "outcome", models.ErrCodeInvalidURL, "error", err)
+		h.errorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidURL, "Only https:// URLs are accepted")
+		return
+	}
+	if err != nil && strings.Contains(err.Error(), "context deadline exceeded") {
+		logger.Warn("validate completed", "status", http.StatusGatewayTimeout, "outcome", models.ErrCodeTimeout, "error", err)
+		h.errorResponse(w, http.StatusGatewayTimeout, models.ErrCodeTimeout, "Validation took too long")
+		return
+	}
+	if err != nil {
+		logger.Warn("validate completed", "status", http.StatusBadGateway, "outcome", models.ErrCodeUpstreamError, "error", err)
+		h.errorResponse(w, http.StatusBadGateway, models.ErrCodeUpstreamError, "URL is not reachable")
+		return
+	}
+
+	result.Metadata = models.Metadata{
+		URL:        targetURL,
+		ScrapedAt:  time.Now(),
+		DurationMs: duration.Milliseconds(),
+	}
+
+	logger.Info("validate completed", "status", http.StatusOK, "outcome", "success", "upstream_status", result.StatusCode)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// discoverResponse finds a site's feeds and sitemaps and writes them
+func (h *CloudRunHandler) discoverResponse(ctx context.Context, w http.ResponseWriter, targetURL string, options scraper.DiscoverOptions, start time.Time, logger *slog.Logger) {
+	result, err := h.scraper.DiscoverWithOptions(ctx, targetURL, options)
+	duration := time.Since(start)
+	logger = logger.With("duration_ms", duration.Milliseconds())
+
+	if errors.Is(err, scraper.ErrHTTPSRequired) {
+		logger.Warn("discover completed", "status", http.StatusBadRequest, "outcome", models.ErrCodeInvalidURL, "error", err)
+		h.errorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidURL, "Only https:// URLs are accepted")
+		return
+	}
+	if err != nil && strings.Contains(err.Error(), "context deadline exceeded") {
+		logger.Warn("discover completed", "status", http.StatusGatewayTimeout, "outcome", models.ErrCodeTimeout, "error", err)
+		h.errorResponse(w, http.StatusGatewayTimeout, models.ErrCodeTimeout, "Discovery took too long")
+		return
+	}
+	if err != nil {
+		logger.Warn("discover completed", "status", http.StatusBadGateway, "outcome", models.ErrCodeUpstreamError, "error", err)
+		h.errorResponse(w, http.StatusBadGateway, models.ErrCodeUpstreamError, "Site is not reachable")
+		return
+	}
+
+	result.Metadata = models.Metadata{
+		URL:        targetURL,
+		ScrapedAt:  time.Now(),
+		DurationMs: duration.Milliseconds(),
+	}
+
+	logger.Info("discover completed", "status", http.StatusOK, "outcome", "success")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// rawResponse fetches the page and writes its HTML unextracted, with the final URL and
+// upstream status in headers
+func (h *CloudRunHandler) rawResponse(ctx context.Context, w http.ResponseWriter, targetURL string, options scraper.ExtractionOptions, start time.Time, logger *slog.Logger) {
+	fetched, err := h.scraper.FetchRaw(ctx, targetURL, options)
+	duration := time.Since(start)
+	logger = logger.With("duration_ms", duration.Milliseconds())
+
+	if scraper.IsCloudflareBlock(err) {
+		logger.Warn("raw completed", "status", http.StatusUnavailableForLegalReasons, "outcome", models.ErrCodeBlocked, "error", err)
+		h.errorResponse(w, http.StatusUnavailableForLegalReasons, models.ErrCodeBlocked, "Blocked by site protection")
+		return
+	}
+	if errors.Is(err, scraper.ErrNotModified) {
+		for _, name := range []string{"ETag", "Last-Modified"} {
+			if value := fetched.Header.Get(name); value != "" {
+				w.Header().Set(name, value)
+			}
+		}
+		logger.Info("raw completed", "status", http.StatusNotModified, "outcome", "not_modified")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if errors.Is(err, scraper.ErrHTTPSRequired) {
+		logger.Warn("raw completed", "status", http.StatusBadRequest, "outcome", models.ErrCodeInvalidURL, "error", err)
+		h.errorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidURL, "Only https:// URLs are accepted")
+		return
+	}
+	if err != nil && strings.Contains(err.Error(), "context deadline exceeded") {
+		logger.Warn("raw completed", "status", http.StatusGatewayTimeout, "outcome", models.ErrCodeTimeout, "error", err)
+		h.errorResponse(w, http.StatusGatewayTimeout, models.ErrCodeTimeout, "Fetch took too long")
+		return
+	}
+	if err != nil {
+		logger.Error("raw completed", "status", http.StatusInternalServerError, "outcome", models.ErrCodeUpstreamError, "error", err)
+		h.errorResponse(w, http.StatusInternalServerError, models.ErrCodeUpstreamError, "Failed to fetch")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set(FinalURLHeader, fetched.URL)
+	if fetched.StatusCode != 0 {
+		w.Header().Set(UpstreamStatusHeader, strconv.Itoa(fetched.StatusCode))
+	}
+
+	logger.Info("raw completed", "status", http.StatusOK, "outcome", "success", "bytes", len(fetched.HTML))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(fetched.HTML))
+}
+
 // errorResponse creates an error response
-func (h *CloudRunHandler) errorResponse(w http.ResponseWriter, statusCode int, message string) {
+func (h *CloudRunHandler) errorResponse(w http.ResponseWriter, statusCode int, code, message string) {
 	errorResp := models.ErrorResponse{
 		Error: message,
+		Code:  code,
 	}
 
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(errorResp)
 }
 
+// serverTiming formats the scrape phases as a Server-Timing header value
+func serverTiming(timing *models.Timing, totalMs int64) string {
+	return fmt.Sprintf("http;dur=%d, browser;dur=%d, extraction;dur=%d, total;dur=%d",
+		timing.HTTPFetchMs, timing.BrowserMs, timing.ExtractionMs, totalMs)
+}
+
+// RequestIDHeader carries the correlation ID in requests and responses
+const RequestIDHeader = "X-Request-Id"
+
+// MaxRequestIDLength caps a client-supplied request ID before it is echoed and logged
+const MaxRequestIDLength = 128
+
+// Raw mode response headers
+const (
+	FinalURLHeader       = "X-Final-Url"
+	UpstreamStatusHeader = "X-Upstream-Status"
+)
+
+// validRequestID reports whether a client-supplied request ID can be echoed and logged
+// as is: non-empty, at most MaxRequestIDLength long and printable ASCII
+func validRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random request ID for requests that don't bring one
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
 // main function
 func main() {
+	// Structured JSON logs for Cloud Logging
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+
 	handler := NewCloudRunHandler()
 
 	port := os.Getenv("PORT")
@@ -159,11 +432,11 @@ func main() {
 		port = "8080"
 	}
 
-	fmt.Printf("Starting server on port %s\n", port)
+	slog.Info("starting server", "port", port)
 	http.HandleFunc("/", handler.Handler)
 
 	if err := http.ListenAndServe(":"+port, nil); err != nil {
-		fmt.Printf("Server failed to start: %v\n", err)
+		slog.Error("server failed to start", "error", err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"extract-html-scraper/internal/models"
+	"extract-html-scraper/internal/scraper"
+)
+
+// newTestHandler returns a handler without API keys
+func newTestHandler() *CloudRunHandler {
+	return &CloudRunHandler{scraper: scraper.NewScraper()}
+}
+
+// serve runs one request through the handler
+func serve(h *CloudRunHandler, target string, query url.Values, header http.Header) *httptest.ResponseRecorder {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("url", target)
+	req := httptest.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	h.Handler(rec, req)
+	return rec
+}
+
+func TestHandlerNotModified(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><article><p>The council approved the new transit plan on Tuesday after months of debate.</p></article></body></html>`)
+	}))
+	defer upstream.Close()
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		raw         bool
+		wantStatus  int
+	}{
+		{"scrape unchanged", `"v1"`, false, http.StatusNotModified},
+		{"scrape changed", `"v0"`, false, http.StatusOK},
+		{"raw unchanged", `"v1"`, true, http.StatusNotModified},
+		{"raw changed", `"v0"`, true, http.StatusOK},
+	}
+
+	h := newTestHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			if tt.raw {
+				query.Set("raw", "true")
+			}
+			rec := serve(h, upstream.URL, query, http.Header{"If-None-Match": {tt.ifNoneMatch}})
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusNotModified && rec.Header().Get("ETag") != `"v1"` {
+				t.Errorf("ETag = %q, want the upstream's", rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestHandlerErrorCodes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `{"error": "not an html page"}`)
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><article><p>The council approved the new transit plan on Tuesday after months of debate.</p></article></body></html>`)
+	})
+	mux.HandleFunc("/members-only", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/account/login?next=/members-only", http.StatusFound)
+	})
+	mux.HandleFunc("/account/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><form><p>Sign in to keep reading our award-winning local journalism.</p><input name="email"><input type="password" name="password"></form></body></html>`)
+	})
+	upstream := httptest.NewServer(mux)
+	defer upstream.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		apiKeys    []string
+		apiKey     string
+		wantStatus int
+		wantCode   string
+	}{
+		{"method not allowed", http.MethodPut, "url=" + upstream.URL + "/article", nil, "", http.StatusMethodNotAllowed, models.ErrCodeMethodNotAllowed},
+		{"missing url", http.MethodGet, "", nil, "", http.StatusBadRequest, models.ErrCodeMissingURL},
+		{"invalid url", http.MethodGet, "url=" + url.QueryEscape("http://[::1"), nil, "", http.StatusBadRequest, models.ErrCodeInvalidURL},
+		{"missing api key", http.MethodGet, "url=" + upstream.URL + "/article", []string{"secret"}, "", http.StatusUnauthorized, models.ErrCodeUnauthorized},
+		{"wrong api key", http.MethodGet, "url=" + upstream.URL + "/article", []string{"secret"}, "guess", http.StatusUnauthorized, models.ErrCodeUnauthorized},
+		{"upstream error", http.MethodGet, "url=" + upstream.URL + "/missing", nil, "", http.StatusInternalServerError, models.ErrCodeUpstreamError},
+		{"unextractable", http.MethodGet, "url=" + upstream.URL + "/json", nil, "", http.StatusUnprocessableEntity, models.ErrCodeUnextractable},
+		{"https required", http.MethodGet, "httpsMode=require&url=" + upstream.URL + "/article", nil, "", http.StatusBadRequest, models.ErrCodeInvalidURL},
+		{"login wall", http.MethodGet, "url=" + upstream.URL + "/members-only", nil, "", http.StatusForbidden, models.ErrCodeLoginRequired},
+		{"success", http.MethodGet, "url=" + upstream.URL + "/article", []string{"secret"}, "secret", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler()
+			h.apiKeys = tt.apiKeys
+
+			req := httptest.NewRequest(tt.method, "/?"+tt.query, nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			h.Handler(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var resp models.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Code != tt.wantCode || resp.Error == "" {
+				t.Errorf("response = %+v, want code %s", resp, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandlerPostHeaders(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<html><head><title>Cookie %s</title></head><body><article><p>The council approved the new transit plan on Tuesday after months of debate.</p></article></body></html>`, r.Header.Get("Cookie"))
+	}))
+	defer upstream.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		body       string
+		wantStatus int
+		wantTitle  string
+	}{
+		{"body header", "", `{"headers": {"Cookie": "session=body"}}`, http.StatusOK, "Cookie session=body"},
+		{"replaces header parameter", "&header=" + url.QueryEscape("Cookie: session=query"), `{"headers": {"cookie": "session=body"}}`, http.StatusOK, "Cookie session=body"},
+		{"empty body", "", "", http.StatusOK, "Cookie"},
+		{"invalid json", "", `{"headers": ["Cookie"]}`, http.StatusBadRequest, ""},
+		{"too large", "", `{"headers": {"X-Padding": "` + strings.Repeat("a", MaxRequestBodyBytes) + `"}}`, http.StatusBadRequest, ""},
+	}
+
+	h := newTestHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/?url="+url.QueryEscape(upstream.URL)+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.Handler(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var resp models.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || resp.Code != models.ErrCodeInvalidBody {
+					t.Errorf("response = %+v (%v), want code %s", resp, err, models.ErrCodeInvalidBody)
+				}
+				return
+			}
+			var resp models.ScrapeResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", resp.Title, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestHandlerValidate(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><body>ok</body></html>")
+	}))
+	defer upstream.Close()
+
+	rec := serve(newTestHandler(), upstream.URL, url.Values{"validate": {"true"}}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+
+	var resp models.ValidateResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || !resp.IsHTML || resp.URL != upstream.URL {
+		t.Errorf("response = %+v", resp)
+	}
+
+	upstream.Close()
+	if rec := serve(newTestHandler(), upstream.URL, url.Values{"validate": {"true"}}, nil); rec.Code != http.StatusBadGateway {
+		t.Errorf("unreachable status = %d, want %d", rec.Code, http.StatusBadGateway)
+	}
+}
+
+func TestHandlerRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		echoed   bool
+	}{
+		{"echoed", "req-42", true},
+		{"generated when absent", "", false},
+		{"replaced when too long", strings.Repeat("a", MaxRequestIDLength+1), false},
+		{"replaced when not printable", "req 42", false},
+	}
+
+	h := newTestHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.incoming != "" {
+				header.Set(RequestIDHeader, tt.incoming)
+			}
+			// No url, so the request ends without a fetch
+			rec := serve(h, "", nil, header)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if tt.echoed && got != tt.incoming {
+				t.Errorf("%s = %q, want %q", RequestIDHeader, got, tt.incoming)
+			}
+			if !tt.echoed && (got == "" || got == tt.incoming) {
+				t.Errorf("%s = %q, want a generated ID", RequestIDHeader, got)
+			}
+		})
+	}
+}
+
+func TestServerTiming(t *testing.T) {
+	tests := []struct {
+		name   string
+		timing models.Timing
+		total  int64
+		want   string
+	}{
+		{"http only", models.Timing{HTTPFetchMs: 120, ExtractionMs: 15}, 140, "http;dur=120, browser;dur=0, extraction;dur=15, total;dur=140"},
+		{"browser fallback", models.Timing{HTTPFetchMs: 80, BrowserMs: 2400, ExtractionMs: 30}, 2520, "http;dur=80, browser;dur=2400, extraction;dur=30, total;dur=2520"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serverTiming(&tt.timing, tt.total); got != tt.want {
+				t.Errorf("serverTiming() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerTiming(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><article><p>The council approved the new transit plan on Tuesday after months of debate.</p></article></body></html>`)
+	}))
+	defer upstream.Close()
+
+	rec := serve(newTestHandler(), upstream.URL, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+
+	var resp models.ScrapeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	timing := resp.Metadata.Timing
+	if timing == nil {
+		t.Fatal("metadata has no timing")
+	}
+	if timing.HTTPFetchMs < 50 || timing.BrowserMs != 0 {
+		t.Errorf("timing = %+v, want the upstream delay in the HTTP phase", timing)
+	}
+	if sum := timing.HTTPFetchMs + timing.BrowserMs + timing.ExtractionMs; sum > resp.Metadata.DurationMs {
+		t.Errorf("phases sum to %dms, more than the %dms total", sum, resp.Metadata.DurationMs)
+	}
+	if want := serverTiming(timing, resp.Metadata.DurationMs); rec.Header().Get("Server-Timing") != want {
+		t.Errorf("Server-Timing = %q, want %q", rec.Header().Get("Server-Timing"), want)
+	}
+}
+
+func TestHandlerRaw(t *testing.T) {
+	const page = `<html><head><title>Raw Page</title><script>var tracking = 1;</script></head><body><div class="ad">Ad slot</div><p>Unprocessed &amp; untouched.</p></body></html>`
+
+	var gotLanguage string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotLanguage = r.Header.Get("Accept-Language")
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, page)
+	}))
+	defer upstream.Close()
+
+	tests := []struct {
+		name         string
+		query        url.Values
+		wantLanguage string
+	}{
+		{"default fetch options", url.Values{"raw": {"true"}}, scraper.DefaultAcceptLanguage},
+		{"request fetch options applied", url.Values{"raw": {"true"}, "acceptLanguage": {"de-DE"}}, "de-DE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(newTestHandler(), upstream.URL+"/story", tt.query, nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+			}
+
+			if rec.Body.String() != page {
+				t.Errorf("body = %q, want the upstream HTML unchanged", rec.Body)
+			}
+			if got := rec.Header().Get(FinalURLHeader); got != upstream.URL+"/story" {
+				t.Errorf("%s = %q", FinalURLHeader, got)
+			}
+			if got := rec.Header().Get(UpstreamStatusHeader); got != "200" {
+				t.Errorf("%s = %q, want 200", UpstreamStatusHeader, got)
+			}
+			if gotLanguage != tt.wantLanguage {
+				t.Errorf("upstream Accept-Language = %q, want %q", gotLanguage, tt.wantLanguage)
+			}
+		})
+	}
+}
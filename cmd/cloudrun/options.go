@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"extract-html-scraper/internal/config"
+	"extract-html-scraper/internal/scraper"
+)
+
+// parseExtractionOptions builds extraction options from the request query parameters
+func parseExtractionOptions(query url.Values) scraper.ExtractionOptions {
+	opts := scraper.DefaultExtractionOptions()
+
+	opts.StripTrackingParams = queryBool(query, "stripTrackingParams", opts.StripTrackingParams)
+	if order := query.Get("imageOrder"); order == scraper.ImageOrderScore || order == scraper.ImageOrderDocument {
+		opts.ImageOrder = order
+	}
+	opts.PreferOGMainImage = queryBool(query, "preferOgMainImage", opts.PreferOGMainImage)
+	opts.MergeOGImage = queryBool(query, "mergeOgImage", opts.MergeOGImage)
+	opts.IncludeVideos = queryBool(query, "includeVideos", opts.IncludeVideos)
+	opts.FullPage = queryBool(query, "fullPage", opts.FullPage)
+	opts.StrictSelector = strings.TrimSpace(query.Get("strictSelector"))
+	switch mode := query.Get("indexMode"); mode {
+	case scraper.IndexModeOff, scraper.IndexModeLargest, scraper.IndexModeLinks:
+		opts.IndexMode = mode
+	}
+	opts.IncludeTitleCandidates = queryBool(query, "includeTitleCandidates", opts.IncludeTitleCandidates)
+	opts.IncludeRecipe = queryBool(query, "includeRecipe", opts.IncludeRecipe)
+	opts.IncludeBreadcrumbs = queryBool(query, "includeBreadcrumbs", opts.IncludeBreadcrumbs)
+	opts.IncludeStructuredData = queryBool(query, "includeStructuredData", opts.IncludeStructuredData)
+	opts.IncludeMetaTags = queryBool(query, "includeMetaTags", opts.IncludeMetaTags)
+	opts.FollowPagination = queryBool(query, "followPagination", opts.FollowPagination)
+	opts.MaxPages = queryInt(query, "maxPages", opts.MaxPages)
+	opts.MaxContentLength = queryInt(query, "maxContentLength", opts.MaxContentLength)
+	opts.ChunkSize = queryInt(query, "chunkSize", opts.ChunkSize)
+	opts.Preview = queryBool(query, "preview", opts.Preview)
+	opts.IncludeMarkdown = queryBool(query, "includeMarkdown", opts.IncludeMarkdown)
+	opts.KeepInlineImages = queryBool(query, "keepInlineImages", opts.KeepInlineImages)
+	opts.IncludeDiagnostics = queryBool(query, "includeDiagnostics", opts.IncludeDiagnostics)
+	opts.IncludeParagraphs = queryBool(query, "includeParagraphs", opts.IncludeParagraphs)
+	opts.IncludeSections = queryBool(query, "includeSections", opts.IncludeSections)
+	opts.IncludeMainImageSize = queryBool(query, "includeMainImageSize", opts.IncludeMainImageSize)
+	opts.PreserveLineBreaks = queryBool(query, "preserveLineBreaks", opts.PreserveLineBreaks)
+	opts.MarkHeadings = queryBool(query, "markHeadings", opts.MarkHeadings)
+	opts.ValidateImages = queryBool(query, "validateImages", opts.ValidateImages)
+	opts.IncludeThumbnail = queryBool(query, "includeThumbnail", opts.IncludeThumbnail)
+	opts.IncludeQuotes = queryBool(query, "includeQuotes", opts.IncludeQuotes)
+	opts.IncludeTOC = queryBool(query, "includeToc", opts.IncludeTOC)
+	opts.IncludeImagePositions = queryBool(query, "includeImagePositions", opts.IncludeImagePositions)
+	opts.IncludeComments = queryBool(query, "includeComments", opts.IncludeComments)
+	opts.IncludeReadingLevel = queryBool(query, "includeReadingLevel", opts.IncludeReadingLevel)
+	opts.PaywallFallback = queryBool(query, "paywallFallback", opts.PaywallFallback)
+	opts.BrowserWindowWidth = queryInt(query, "browserWidth", opts.BrowserWindowWidth)
+	opts.BrowserWindowHeight = queryInt(query, "browserHeight", opts.BrowserWindowHeight)
+	if device := query.Get("browserDevice"); device == scraper.DeviceDesktop || device == scraper.DeviceMobile {
+		opts.BrowserDevice = device
+	}
+	opts.SettleDelayMs = queryInt(query, "settleDelayMs", opts.SettleDelayMs)
+	if lang := query.Get("acceptLanguage"); scraper.ValidAcceptLanguage(lang) {
+		opts.AcceptLanguage = lang
+	}
+	if query.Has("referer") && scraper.ValidReferer(query.Get("referer")) {
+		opts.Referer = query.Get("referer")
+	}
+	opts.Headers = queryHeaders(query["header"])
+	opts.DismissConsent = queryBool(query, "dismissConsent", opts.DismissConsent)
+	opts.BlankRetries = queryNonNegativeInt(query, "blankRetries", opts.BlankRetries)
+	opts.MaxBodyBytes = queryInt(query, "maxBodyBytes", opts.MaxBodyBytes)
+	switch mode := query.Get("httpsMode"); mode {
+	case scraper.HTTPSModeAllow, scraper.HTTPSModeUpgrade, scraper.HTTPSModeRequire:
+		opts.HTTPSMode = mode
+	}
+	opts.DisableAlternates = queryBool(query, "disableAlternates", opts.DisableAlternates)
+	opts.ExtractPDF = queryBool(query, "extractPdf", opts.ExtractPDF)
+	opts.IncludeResponseInfo = queryBool(query, "includeResponseInfo", opts.IncludeResponseInfo)
+	opts.IncludeRedirects = queryBool(query, "includeRedirects", opts.IncludeRedirects)
+	opts.UseMicrodata = queryBool(query, "useMicrodata", opts.UseMicrodata)
+	opts.UseAppData = queryBool(query, "useAppData", opts.UseAppData)
+	opts.UseTemplates = queryBool(query, "useTemplates", opts.UseTemplates)
+	opts.WordsPerMinute = queryInt(query, "wordsPerMinute", opts.WordsPerMinute)
+	opts.CharsPerMinute = queryInt(query, "charsPerMinute", opts.CharsPerMinute)
+	opts.MinImageShortSide = queryInt(query, "minImageShortSide", opts.MinImageShortSide)
+	opts.MinImageArea = queryInt(query, "minImageArea", opts.MinImageArea)
+	opts.MinImageAspect = queryFloat(query, "minImageAspect", opts.MinImageAspect)
+	opts.MaxImageAspect = queryFloat(query, "maxImageAspect", opts.MaxImageAspect)
+	opts.FilterImageSize = queryBool(query, "filterImageSize", opts.FilterImageSize)
+	opts.FilterImageAspect = queryBool(query, "filterImageAspect", opts.FilterImageAspect)
+	opts.FilterAdSizes = queryBool(query, "filterAdSizes", opts.FilterAdSizes)
+	opts.FilterBadHints = queryBool(query, "filterBadHints", opts.FilterBadHints)
+	opts.AdSizeTolerance = queryNonNegativeInt(query, "adSizeTolerance", opts.AdSizeTolerance)
+	if exts := query.Get("imageExtensions"); exts != "" {
+		opts.ImageExtensions = strings.Split(exts, ",")
+	}
+	if hosts := query.Get("deniedImageHosts"); hosts != "" {
+		opts.DeniedImageHosts = config.ParseHostList(hosts)
+	}
+	opts.AllowExtensionlessImages = queryBool(query, "allowExtensionlessImages", opts.AllowExtensionlessImages)
+
+	return opts
+}
+
+// queryHeaders reads repeated "Name: value" header parameters; the scraper drops
+// invalid and blocked ones, see scraper.CleanRequestHeaders
+func queryHeaders(values []string) map[string]string {
+	var headers map[string]string
+	for _, value := range values {
+		name, headerValue, found := strings.Cut(value, ":")
+		if name = strings.TrimSpace(name); !found || name == "" {
+			continue
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[name] = strings.TrimSpace(headerValue)
+	}
+	return headers
+}
+
+// MaxRequestBodyBytes caps the JSON body of a POST request
+const MaxRequestBodyBytes = 64 << 10
+
+// requestBody is the JSON body of a POST request, carrying the options that shouldn't
+// travel in the query string
+type requestBody struct {
+	Headers map[string]string `json:"headers"`
+}
+
+// readRequestBody decodes a POST body; an empty body is the same as no options
+func readRequestBody(w http.ResponseWriter, r *http.Request) (requestBody, error) {
+	var body requestBody
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestBodyBytes)).Decode(&body)
+	if errors.Is(err, io.EOF) {
+		return requestBody{}, nil
+	}
+	return body, err
+}
+
+// mergeHeaders adds body headers to header parameters, replacing parameters of the same
+// name whatever their case
+func mergeHeaders(query, body map[string]string) map[string]string {
+	if len(body) == 0 {
+		return query
+	}
+
+	merged := make(map[string]string, len(query)+len(body))
+	for name, value := range query {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+	for name, value := range body {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+	return merged
+}
+
+// queryBool reads a boolean query parameter, returning fallback when absent or invalid
+func queryBool(query url.Values, name string, fallback bool) bool {
+	value := query.Get(name)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+// queryInt reads a positive integer query parameter, returning fallback when absent or invalid
+func queryInt(query url.Values, name string, fallback int) int {
+	value := query.Get(name)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return fallback
+	}
+	return parsed
+}
+
+// queryNonNegativeInt reads an integer query parameter for counts where 0 is meaningful,
+// returning fallback when absent, negative or invalid
+func queryNonNegativeInt(query url.Values, name string, fallback int) int {
+	value := query.Get(name)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return fallback
+	}
+	return parsed
+}
+
+// queryFloat reads a positive float query parameter, returning fallback when absent or invalid
+func queryFloat(query url.Values, name string, fallback float64) float64 {
+	value := query.Get(name)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 {
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"extract-html-scraper/internal/scraper"
+)
+
+func TestParseBrowserViewport(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantWidth  int
+		wantHeight int
+		wantDevice string
+	}{
+		{"defaults", "", 0, 0, scraper.DeviceDesktop},
+		{"custom viewport", "browserWidth=390&browserHeight=844", 390, 844, scraper.DeviceDesktop},
+		{"mobile device", "browserDevice=mobile", 0, 0, scraper.DeviceMobile},
+		{"unknown device ignored", "browserDevice=tablet", 0, 0, scraper.DeviceDesktop},
+		{"invalid width ignored", "browserWidth=wide", 0, 0, scraper.DeviceDesktop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			opts := parseExtractionOptions(query)
+			if opts.BrowserWindowWidth != tt.wantWidth || opts.BrowserWindowHeight != tt.wantHeight || opts.BrowserDevice != tt.wantDevice {
+				t.Errorf("options = %dx%d %q, want %dx%d %q", opts.BrowserWindowWidth, opts.BrowserWindowHeight, opts.BrowserDevice, tt.wantWidth, tt.wantHeight, tt.wantDevice)
+			}
+		})
+	}
+}
+
+func TestParseBlankRetries(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"default", "", scraper.DefaultBlankRetries},
+		{"zero disables", "blankRetries=0", 0},
+		{"requested", "blankRetries=2", 2},
+		{"negative ignored", "blankRetries=-1", scraper.DefaultBlankRetries},
+		{"invalid ignored", "blankRetries=many", scraper.DefaultBlankRetries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			if got := parseExtractionOptions(query).BlankRetries; got != tt.want {
+				t.Errorf("BlankRetries = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReferer(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"default", "", scraper.DefaultReferer},
+		{"configured", "referer=https://www.facebook.com/", "https://www.facebook.com/"},
+		{"empty sends none", "referer=", ""},
+		{"invalid ignored", "referer=javascript:alert(1)", scraper.DefaultReferer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			if got := parseExtractionOptions(query).Referer; got != tt.want {
+				t.Errorf("Referer = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  map[string]string
+	}{
+		{"none", "", nil},
+		{"single", "header=X-Api-Token:%20secret", map[string]string{"X-Api-Token": "secret"}},
+		{"repeated", "header=X-Api-Token:a&header=Accept-Language:%20fr", map[string]string{"X-Api-Token": "a", "Accept-Language": "fr"}},
+		{"value with colon", "header=X-Origin:%20https://example.com", map[string]string{"X-Origin": "https://example.com"}},
+		{"malformed skipped", "header=novalue&header=:empty", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			if got := parseExtractionOptions(query).Headers; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Headers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeHeaders(t *testing.T) {
+	tests := []struct {
+		name  string
+		query map[string]string
+		body  map[string]string
+		want  map[string]string
+	}{
+		{"no body", map[string]string{"x-a": "1"}, nil, map[string]string{"x-a": "1"}},
+		{"body only", nil, map[string]string{"Cookie": "a=1"}, map[string]string{"Cookie": "a=1"}},
+		{"body wins", map[string]string{"cookie": "q", "X-A": "1"}, map[string]string{"COOKIE": "b"}, map[string]string{"Cookie": "b", "X-A": "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeHeaders(tt.query, tt.body)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
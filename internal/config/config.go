@@ -3,10 +3,14 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // ImageConfig contains configuration for image extraction
@@ -18,7 +22,10 @@ type ImageConfig struct {
 	RatioWhitelist []float64
 	RatioTol       float64
 	AdSizes        map[string]bool
+	AdSizeTol      int // Pixels either dimension may be off a known ad size; 0 matches exactly
 	BadHintRegex   string
+	Extensions     []string // Accepted image file extensions, lowercase without the dot
+	DeniedHosts    []string // Hosts, subdomains included, whose images are always rejected
 }
 
 // ScrapeConfig contains general scraping configuration
@@ -26,8 +33,61 @@ type ScrapeConfig struct {
 	UserAgent      string
 	TimeoutMs      int
 	SizeLimitBytes int
-	MaxRetries     int
+	MaxRetries     int // HTTP retries per fetch
+	MaxAttempts    int // Whole-scrape attempts (HTTP then browser), 1 disables retrying
 	ChromeMajor    int
+	RateLimitRPS   float64 // Requests per second per host, 0 disables
+	RateLimitBurst int
+
+	// AllowedContentTypes are the media types fetched pages may have
+	AllowedContentTypes []string
+
+	// ChromePath is the browser binary (empty autodetects it) and ChromeExtraFlags are
+	// appended to its launch flags, as "--name" or "--name=value"
+	ChromePath       string
+	ChromeExtraFlags []string
+
+	// HTTP connection pool sizes, and how long resolved host addresses are reused
+	// (zero disables the DNS cache)
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	DNSCacheTTL         time.Duration
+
+	// DisableAlternates stops falling back to AMP/mobile variants of a blocked URL
+	DisableAlternates bool
+
+	// Deadline caps a whole scrape, every phase and attempt included (zero leaves it
+	// to the caller's context)
+	Deadline time.Duration
+
+	// HTTPSMode is how http:// targets are handled: "allow", "upgrade" or "require"
+	HTTPSMode string
+
+	// AllowHostHeader lets a request's custom headers override Host on the HTTP fetch
+	AllowHostHeader bool
+}
+
+// ScoreBand awards Points when a metric reaches at least Min
+type ScoreBand struct {
+	Min    float64 `json:"min"`
+	Points int     `json:"points"`
+}
+
+// CeilingBand awards Points when a metric stays at or below Max
+type CeilingBand struct {
+	Max    float64 `json:"max"`
+	Points int     `json:"points"`
+}
+
+// QualityConfig contains the content-quality scoring thresholds.
+// Bands are evaluated in order and the first match wins.
+type QualityConfig struct {
+	WordCountBands          []ScoreBand   `json:"wordCountBands"`
+	ParagraphCountBands     []ScoreBand   `json:"paragraphCountBands"`
+	AvgParagraphLengthBands []ScoreBand   `json:"avgParagraphLengthBands"`
+	HeadingsPoints          int           `json:"headingsPoints"`
+	TextToHTMLRatioBands    []ScoreBand   `json:"textToHtmlRatioBands"`
+	LinkDensityBands        []CeilingBand `json:"linkDensityBands"`
 }
 
 // DefaultImageConfig returns the default image extraction configuration
@@ -47,9 +107,24 @@ func DefaultImageConfig() ImageConfig {
 			"88x31": true,
 		},
 		BadHintRegex: `(sprite|icon|favicon|logo|avatar|emoji|placeholder|pixel|tracker|ads?|adserver|promo|beacon)`,
+		Extensions:   []string{"jpg", "jpeg", "png", "gif", "webp", "avif"},
+		DeniedHosts:  ParseHostList(os.Getenv("SCRAPE_IMAGE_DENYLIST")),
 	}
 }
 
+// ParseHostList splits a comma-separated host list, lowercased, with any "*." or "."
+// prefix dropped since subdomains always match
+func ParseHostList(raw string) []string {
+	var hosts []string
+	for _, host := range strings.Split(raw, ",") {
+		host = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(host)), "*"), ".")
+		if host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
 // DefaultScrapeConfig returns the default scraping configuration
 func DefaultScrapeConfig() ScrapeConfig {
 	chromeMajor := 133
@@ -64,13 +139,154 @@ func DefaultScrapeConfig() ScrapeConfig {
 		userAgent = fmt.Sprintf("Mozilla/5.0 (Windows NT 10; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.6943.126 Safari/537.36", chromeMajor)
 	}
 
+	rateLimitRPS := 2.0
+	if env := os.Getenv("SCRAPE_RATE_LIMIT_RPS"); env != "" {
+		if parsed, err := strconv.ParseFloat(env, 64); err == nil {
+			rateLimitRPS = parsed
+		}
+	}
+
+	rateLimitBurst := 4
+	if env := os.Getenv("SCRAPE_RATE_LIMIT_BURST"); env != "" {
+		if parsed, err := strconv.Atoi(env); err == nil {
+			rateLimitBurst = parsed
+		}
+	}
+
+	contentTypes := []string{"text/html", "application/xhtml+xml"}
+	if env := os.Getenv("SCRAPE_CONTENT_TYPES"); env != "" {
+		contentTypes = nil
+		for _, contentType := range strings.Split(env, ",") {
+			if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+				contentTypes = append(contentTypes, contentType)
+			}
+		}
+	}
+
+	maxAttempts := 1
+	if env := os.Getenv("SCRAPE_MAX_ATTEMPTS"); env != "" {
+		if parsed, err := strconv.Atoi(env); err == nil && parsed >= 1 {
+			maxAttempts = parsed
+		}
+	}
+
+	maxIdleConns := 100
+	if env := os.Getenv("SCRAPE_MAX_IDLE_CONNS"); env != "" {
+		if parsed, err := strconv.Atoi(env); err == nil && parsed >= 0 {
+			maxIdleConns = parsed
+		}
+	}
+
+	maxIdleConnsPerHost := 10
+	if env := os.Getenv("SCRAPE_MAX_IDLE_CONNS_PER_HOST"); env != "" {
+		if parsed, err := strconv.Atoi(env); err == nil && parsed > 0 {
+			maxIdleConnsPerHost = parsed
+		}
+	}
+
+	var dnsCacheTTL time.Duration
+	if env := os.Getenv("SCRAPE_DNS_CACHE_TTL"); env != "" {
+		if parsed, err := time.ParseDuration(env); err == nil && parsed > 0 {
+			dnsCacheTTL = parsed
+		}
+	}
+
+	disableAlternates, _ := strconv.ParseBool(os.Getenv("SCRAPE_DISABLE_ALTERNATES"))
+	allowHostHeader, _ := strconv.ParseBool(os.Getenv("SCRAPE_ALLOW_HOST_HEADER"))
+
+	httpsMode := "allow"
+	switch env := strings.ToLower(os.Getenv("SCRAPE_HTTPS_MODE")); env {
+	case "allow", "upgrade", "require":
+		httpsMode = env
+	}
+
+	var deadline time.Duration
+	if env := os.Getenv("SCRAPE_DEADLINE"); env != "" {
+		if parsed, err := time.ParseDuration(env); err == nil && parsed > 0 {
+			deadline = parsed
+		}
+	}
+
+	// CHROME_BIN is the older name of CHROME_PATH
+	chromePath := os.Getenv("CHROME_PATH")
+	if chromePath == "" {
+		chromePath = os.Getenv("CHROME_BIN")
+	}
+
 	return ScrapeConfig{
 		UserAgent:      userAgent,
 		TimeoutMs:      15000,
 		SizeLimitBytes: 6_000_000,
 		MaxRetries:     2,
+		MaxAttempts:    maxAttempts,
 		ChromeMajor:    chromeMajor,
+		RateLimitRPS:   rateLimitRPS,
+		RateLimitBurst: rateLimitBurst,
+
+		AllowedContentTypes: contentTypes,
+
+		ChromePath:       chromePath,
+		ChromeExtraFlags: strings.Fields(os.Getenv("CHROME_EXTRA_FLAGS")),
+
+		MaxIdleConns:        maxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		DNSCacheTTL:         dnsCacheTTL,
+
+		DisableAlternates: disableAlternates,
+
+		Deadline:  deadline,
+		HTTPSMode: httpsMode,
+
+		AllowHostHeader: allowHostHeader,
+	}
+}
+
+// DefaultQualityConfig returns the default content-quality scoring thresholds
+func DefaultQualityConfig() QualityConfig {
+	return QualityConfig{
+		WordCountBands: []ScoreBand{
+			{Min: 500, Points: 25}, {Min: 200, Points: 20}, {Min: 100, Points: 15}, {Min: 50, Points: 10},
+		},
+		ParagraphCountBands: []ScoreBand{
+			{Min: 5, Points: 20}, {Min: 3, Points: 15}, {Min: 2, Points: 10}, {Min: 1, Points: 5},
+		},
+		AvgParagraphLengthBands: []ScoreBand{
+			{Min: 200, Points: 20}, {Min: 100, Points: 15}, {Min: 50, Points: 10}, {Min: 20, Points: 5},
+		},
+		HeadingsPoints: 15,
+		TextToHTMLRatioBands: []ScoreBand{
+			{Min: 0.3, Points: 10}, {Min: 0.2, Points: 7}, {Min: 0.1, Points: 5},
+		},
+		LinkDensityBands: []CeilingBand{
+			{Max: 0.1, Points: 10}, {Max: 0.25, Points: 5}, {Max: 0.5, Points: 0}, {Max: 1, Points: -10},
+		},
+	}
+}
+
+// LoadQualityConfig returns the default quality config overlaid with the
+// SCRAPE_QUALITY_CONFIG environment variable (JSON), if set
+func LoadQualityConfig() QualityConfig {
+	cfg := DefaultQualityConfig()
+
+	if env := os.Getenv("SCRAPE_QUALITY_CONFIG"); env != "" {
+		override := cfg
+		if err := json.Unmarshal([]byte(env), &override); err == nil {
+			cfg = override
+		} else {
+			slog.Warn("ignoring invalid SCRAPE_QUALITY_CONFIG", "error", err)
+		}
+	}
+
+	return cfg
+}
+
+// ImageExtRegex matches URLs whose path ends in one of the given image extensions
+func ImageExtRegex(extensions []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		quoted = append(quoted, regexp.QuoteMeta(strings.ToLower(strings.TrimPrefix(ext, "."))))
 	}
+	return regexp.MustCompile(`\.(` + strings.Join(quoted, "|") + `)(?:$|[?#])`)
 }
 
 // CompileRegexes pre-compiles regex patterns for better performance
@@ -89,16 +305,28 @@ func CompileRegexes() map[string]*regexp.Regexp {
 		"widthStyle":        regexp.MustCompile(`(?:^|;|\s)width\s*:\s*(\d+(?:\.\d+)?)px\b`),
 		"heightStyle":       regexp.MustCompile(`(?:^|;|\s)height\s*:\s*(\d+(?:\.\d+)?)px\b`),
 		"srcsetAttr":        regexp.MustCompile(`srcset=["']([^"']+)["']`),
-		"srcsetItem":        regexp.MustCompile(`(\S+)\s+(\d+)w`),
 		"dimensionsFromUrl": regexp.MustCompile(`(?:^|[^\d])(\d{3,4})x(\d{3,4})(?:[^\d]|$)`),
 		"widthFromUrl":      regexp.MustCompile(`[?&](?:w|width)=(\d{3,4})\b`),
 		"heightFromUrl":     regexp.MustCompile(`[?&](?:h|height)=(\d{3,4})\b`),
-		"imageExt":          regexp.MustCompile(`\.(jpe?g|png|gif|webp|avif)(?:$|[?#])`),
+		"imageExt":          ImageExtRegex(config.Extensions),
 		"ogImage":           regexp.MustCompile(`<meta[^>]*property=["']og:image(?::secure_url)?["'][^>]*content=["']([^"']+)["']`),
 		"ogWidth":           regexp.MustCompile(`<meta[^>]*property=["']og:image:width["'][^>]*content=["']([^"']+)["']`),
 		"ogHeight":          regexp.MustCompile(`<meta[^>]*property=["']og:image:height["'][^>]*content=["']([^"']+)["']`),
 		"articleTag":        regexp.MustCompile(`<(article|main)[\s>]`),
 		"closeArticleTag":   regexp.MustCompile(`</(article|main)>`),
+		"bylinePrefix":      regexp.MustCompile(`(?i)^(?:by|written by|words by)\s*:?\s+`),
+		"bylineSplit":       regexp.MustCompile(`(?i)\s*(?:,|;|&|\band\b|\bwith (?:additional )?reporting (?:by|from)\b)\s*`),
+		"youtubeEmbed":      regexp.MustCompile(`(?i)(?:youtube(?:-nocookie)?\.com/(?:embed|v|shorts)/|youtube\.com/watch\?(?:.*&)?v=|youtu\.be/)([A-Za-z0-9_-]{11})`),
+		"vimeoEmbed":        regexp.MustCompile(`(?i)vimeo\.com/(?:video/)?(\d+)`),
+		"metaTag":           regexp.MustCompile(`(?i)<meta\b[^>]*>`),
+		"refreshEquiv":      regexp.MustCompile(`(?i)http-equiv\s*=\s*["']?refresh\b`),
+		"contentAttr":       regexp.MustCompile(`(?i)content\s*=\s*(?:"([^"]*)"|'([^']*)')`),
+		"refreshURL":        regexp.MustCompile(`(?i)^\s*(\d+(?:\.\d+)?)?\s*[;,]?\s*url\s*=\s*['"]?([^'"]+?)['"]?\s*$`),
+		"jsRedirect":        regexp.MustCompile(`(?i)(?:window\.|document\.|top\.|self\.)?location(?:\.href)?\s*=\s*["']([^"']+)["']|location\.(?:replace|assign)\(\s*["']([^"']+)["']\s*\)`),
 		"cfBlock":           regexp.MustCompile(`(attention required|cloudflare ray id|what can i do to resolve this\?|why have i been blocked\?|performance & security by cloudflare)`),
+		"imageSizeSuffix":   regexp.MustCompile(`(?i)(?:[-_]\d{2,4}x\d{2,4}|@\d(?:\.\d+)?x|-scaled)$`),
+		"cloudinaryStep":    regexp.MustCompile(`^[a-z]{1,3}_[^/,]+(?:,[a-z]{1,3}_[^/,]+)*$`),
+		"imageSizeSegment":  regexp.MustCompile(`(?i)^(?:\d{2,4}x\d{2,4}|(?:w|h|c|q|f|ar|dpr|g)_[^/]*|(?:resize|fit|crop)(?:[:=][^/]*)?)$`),
+		"soft404":           regexp.MustCompile(`(?i)^\s*not found\b|\b(?:404|page not found|page (?:does not|doesn't|no longer) exists?|page (?:is )?(?:no longer available|unavailable)|couldn't find (?:that|this|the) page|nothing (?:was )?found)\b`),
 	}
 }
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadQualityConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            string
+		wantHeadings   int
+		wantWordBands  int
+		wantFirstPoint int
+	}{
+		{"unset", "", 15, 4, 25},
+		{"override one field", `{"headingsPoints": 5}`, 5, 4, 25},
+		{"override bands", `{"wordCountBands": [{"min": 10, "points": 30}]}`, 15, 1, 30},
+		{"invalid json keeps defaults", `{"headingsPoints": `, 15, 4, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SCRAPE_QUALITY_CONFIG", tt.env)
+			cfg := LoadQualityConfig()
+
+			if cfg.HeadingsPoints != tt.wantHeadings {
+				t.Errorf("HeadingsPoints = %d, want %d", cfg.HeadingsPoints, tt.wantHeadings)
+			}
+			if len(cfg.WordCountBands) != tt.wantWordBands || cfg.WordCountBands[0].Points != tt.wantFirstPoint {
+				t.Errorf("WordCountBands = %+v", cfg.WordCountBands)
+			}
+		})
+	}
+}
+
+func TestAllowedContentTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want []string
+	}{
+		{"default", "", []string{"text/html", "application/xhtml+xml"}},
+		{"override", " Text/HTML , application/xml,, ", []string{"text/html", "application/xml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SCRAPE_CONTENT_TYPES", tt.env)
+			got := DefaultScrapeConfig().AllowedContentTypes
+			if len(got) != len(tt.want) {
+				t.Fatalf("AllowedContentTypes = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("AllowedContentTypes = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestChromeEnvironment(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		bin       string
+		flags     string
+		wantPath  string
+		wantFlags int
+	}{
+		{"autodetect", "", "", "", "", 0},
+		{"chrome path", "/opt/chrome/chrome", "", "", "/opt/chrome/chrome", 0},
+		{"legacy chrome bin", "", "/usr/bin/chromium", "", "/usr/bin/chromium", 0},
+		{"chrome path wins", "/opt/chrome/chrome", "/usr/bin/chromium", "", "/opt/chrome/chrome", 0},
+		{"extra flags", "", "", "--disable-http2  --lang=fr", "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CHROME_PATH", tt.path)
+			t.Setenv("CHROME_BIN", tt.bin)
+			t.Setenv("CHROME_EXTRA_FLAGS", tt.flags)
+
+			cfg := DefaultScrapeConfig()
+			if cfg.ChromePath != tt.wantPath || len(cfg.ChromeExtraFlags) != tt.wantFlags {
+				t.Errorf("ChromePath %q, ChromeExtraFlags %q, want %q and %d flags", cfg.ChromePath, cfg.ChromeExtraFlags, tt.wantPath, tt.wantFlags)
+			}
+		})
+	}
+}
+
+func TestParseHostList(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"trimmed and lowercased", " Pixel.Example.com , ads.example.net", []string{"pixel.example.com", "ads.example.net"}},
+		{"wildcard and dot prefixes dropped", "*.tracker.io,.cdn.example.org", []string{"tracker.io", "cdn.example.org"}},
+		{"blank entries skipped", "a.com,, ,b.com", []string{"a.com", "b.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseHostList(tt.raw)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseHostList(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseHostList(%q) = %q, want %q", tt.raw, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestScrapeDeadline(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want time.Duration
+	}{
+		{"unset", "", 0},
+		{"duration", "30s", 30 * time.Second},
+		{"invalid", "soon", 0},
+		{"negative", "-5s", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SCRAPE_DEADLINE", tt.env)
+			if got := DefaultScrapeConfig().Deadline; got != tt.want {
+				t.Errorf("Deadline = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPSModeEnvironment(t *testing.T) {
+	tests := []struct {
+		env  string
+		want string
+	}{
+		{"", "allow"},
+		{"upgrade", "upgrade"},
+		{"REQUIRE", "require"},
+		{"strict", "allow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv("SCRAPE_HTTPS_MODE", tt.env)
+			if got := DefaultScrapeConfig().HTTPSMode; got != tt.want {
+				t.Errorf("HTTPSMode = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -45,6 +45,16 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("HTTP %d for URL %s: %v", e.StatusCode, e.URL, e.Err)
 }
 
+// LoginRequiredError is returned when the page redirected to, or turned out to be, a
+// login form instead of the article
+type LoginRequiredError struct {
+	URL string // Login page the fetch landed on
+}
+
+func (e *LoginRequiredError) Error() string {
+	return fmt.Sprintf("login required: landed on %s", e.URL)
+}
+
 // ContentExtractionError represents an error during content extraction
 type ContentExtractionError struct {
 	Step string
@@ -54,3 +64,7 @@ type ContentExtractionError struct {
 func (e *ContentExtractionError) Error() string {
 	return fmt.Sprintf("content extraction failed at %s: %v", e.Step, e.Err)
 }
+
+func (e *ContentExtractionError) Unwrap() error {
+	return e.Err
+}
@@ -16,29 +16,135 @@ type Quality struct {
 	ParagraphCount     int     `json:"paragraphCount"`     // Number of paragraphs
 	AvgParagraphLength int     `json:"avgParagraphLength"` // Average characters per paragraph
 	HasHeaders         bool    `json:"hasHeaders"`         // Contains headings
-	LinkDensity        float64 `json:"linkDensity"`        // Links per 1000 chars (lower is better)
+	LinkDensity        float64 `json:"linkDensity"`        // Share of text inside links, 0-1 (lower is better)
 	WordCount          int     `json:"wordCount"`          // Estimated word count
 }
 
+// ReadingLevel rates how hard the content is to read. Syllables are estimated, so the
+// scores are approximate and English-oriented.
+type ReadingLevel struct {
+	FleschReadingEase float64 `json:"fleschReadingEase"` // Roughly 0-100, higher is easier
+	GradeLevel        float64 `json:"gradeLevel"`        // Flesch-Kincaid US school grade
+}
+
+// Recipe is a schema.org Recipe's cooking data. Times are ISO 8601 durations as the
+// page gives them, e.g. "PT1H30M".
+type Recipe struct {
+	Name         string   `json:"name,omitempty"`
+	Ingredients  []string `json:"ingredients,omitempty"`
+	Instructions []string `json:"instructions,omitempty"` // One entry per step
+	TotalTime    string   `json:"totalTime,omitempty"`
+	Yield        string   `json:"yield,omitempty"` // e.g. "4 servings"
+}
+
 // ScrapeResponse represents the successful scraping result
 type ScrapeResponse struct {
-	Title       string   `json:"title,omitempty"`
-	Description string   `json:"description,omitempty"`
-	Content     string   `json:"content,omitempty"`
-	Images      []string `json:"images"`
-	Metadata    Metadata `json:"metadata"`
-	Author      string   `json:"author,omitempty"`
-	PublishDate string   `json:"publishDate,omitempty"`
-	Excerpt     string   `json:"excerpt,omitempty"`
-	ReadingTime int      `json:"readingTime,omitempty"`
-	Language    string   `json:"language,omitempty"`
-	TextLength  int      `json:"textLength,omitempty"`
-	Quality     Quality  `json:"quality,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Slug        string      `json:"slug,omitempty"` // Title as a lowercase-hyphenated, filename-safe slug
+	Content     string      `json:"content,omitempty"`
+	Paragraphs  []string    `json:"paragraphs,omitempty"` // One entry per <p>/<li>/<blockquote>, headings excluded
+	Chunks      []string    `json:"chunks,omitempty"`     // Content split at paragraph breaks, see ChunkSize
+	Sections    []Section   `json:"sections,omitempty"`   // Content grouped under its headings, see IncludeSections
+	Quotes      []string    `json:"quotes,omitempty"`     // Blockquote and pullquote text, also left in Content
+	Comments    []string    `json:"comments,omitempty"`   // Reader comments, kept out of Content, see IncludeComments
+	TOC         []TOCEntry  `json:"toc,omitempty"`        // Table of contents links, see IncludeTOC
+	MainImage   string      `json:"mainImage,omitempty"`  // Hero image, see PreferOGMainImage
+	Images      []string    `json:"images"`
+	Videos      []VideoInfo `json:"videos,omitempty"`
+	Metadata    Metadata    `json:"metadata"`
+	Author      string      `json:"author,omitempty"`
+	Authors     []string    `json:"authors,omitempty"` // All authors; Author is the first
+	PublishDate string      `json:"publishDate,omitempty"`
+	Excerpt     string      `json:"excerpt,omitempty"`
+	ReadingTime int         `json:"readingTime,omitempty"`
+	Language    string      `json:"language,omitempty"`
+	TextLength  int         `json:"textLength,omitempty"`
+	Quality     Quality     `json:"quality,omitempty"`
+
+	ReadingLevel *ReadingLevel `json:"readingLevel,omitempty"` // See IncludeReadingLevel
+	Recipe       *Recipe       `json:"recipe,omitempty"`       // See IncludeRecipe
+
+	Paywalled bool `json:"paywalled,omitempty"` // Content looks cut off by a paywall
+	Truncated bool `json:"truncated,omitempty"` // Content was cut to MaxContentLength; TextLength is the full length
+
+	NextURL string `json:"nextUrl,omitempty"` // Next post in a series (rel="next" or post navigation)
+	PrevURL string `json:"prevUrl,omitempty"` // Previous post in a series
+
+	IndexPage    bool     `json:"indexPage,omitempty"`    // Page lists article teasers, e.g. a homepage
+	ArticleLinks []string `json:"articleLinks,omitempty"` // Teased article URLs on an index page, see IndexMode
+
+	SelectorNotMatched bool `json:"selectorNotMatched,omitempty"` // StrictSelector matched nothing, so Content is empty
+
+	ModifiedDate string `json:"modifiedDate,omitempty"` // Last update; PublishDate stays the original publication
+
+	PublishDateLocal string `json:"publishDateLocal,omitempty"` // PublishDate in the source's own UTC offset, when it gave one
+
+	ImagePositions []ImagePosition `json:"imagePositions,omitempty"` // Content images placed among Paragraphs, see IncludeImagePositions
+
+	AuthorImage string `json:"authorImage,omitempty"` // First author's avatar or profile image
+
+	Locale           string   `json:"locale,omitempty"`           // og:locale, e.g. "en_US"
+	AlternateLocales []string `json:"alternateLocales,omitempty"` // og:locale:alternate values
+
+	TitleCandidates []TitleCandidate `json:"titleCandidates,omitempty"` // Every title found, see IncludeTitleCandidates
+
+	ThemeColor string `json:"themeColor,omitempty"` // <meta name="theme-color">, for preview cards
+	ImageAlt   string `json:"imageAlt,omitempty"`   // og:image:alt (or twitter:image:alt)
+
+	// MainImage size in pixels, when includeMainImageSize is set and the size is known
+	MainImageWidth  int `json:"mainImageWidth,omitempty"`
+	MainImageHeight int `json:"mainImageHeight,omitempty"`
+
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"` // Small MainImage variant on known CDNs, see IncludeThumbnail
+
+	ContentMarkdown string `json:"contentMarkdown,omitempty"` // Content as Markdown, see IncludeMarkdown
+
+	Diagnostics *Diagnostics `json:"diagnostics,omitempty"` // Extraction decisions, see IncludeDiagnostics
+
+	StructuredData []map[string]interface{} `json:"structuredData,omitempty"`
+
+	MetaTags map[string]string `json:"metaTags,omitempty"` // Every head <meta> by property or name, see IncludeMetaTags
+
+	Breadcrumbs []string `json:"breadcrumbs,omitempty"` // Category trail, broadest first, see IncludeBreadcrumbs
+
+	ContentHash      string `json:"contentHash,omitempty"`      // SHA-256 of whitespace-normalized content
+	TitleContentHash string `json:"titleContentHash,omitempty"` // SHA-256 of title + content
+}
+
+// Section is the text under one heading; the intro before the first heading has Level 0
+type Section struct {
+	Heading string `json:"heading,omitempty"`
+	Level   int    `json:"level"` // 1-6 for h1-h6
+	Text    string `json:"text"`  // Paragraphs separated by blank lines
+}
+
+// TitleCandidate is one of the titles a page gives, with where it came from: "og:title",
+// "twitter:title", "h1", "title", "microdata" or "appdata"
+type TitleCandidate struct {
+	Text   string `json:"text"`
+	Source string `json:"source"`
+}
+
+// TOCEntry is one table of contents link. Anchor is the "#fragment" of an in-page
+// link, or the absolute URL of a link leaving the page, which is marked External.
+type TOCEntry struct {
+	Text     string `json:"text"`
+	Anchor   string `json:"anchor"`
+	External bool   `json:"external,omitempty"`
+}
+
+// ImagePosition places a content image in the paragraph stream: ParagraphIndex is the
+// number of paragraphs before it, so 0 is above the first and 2 sits after the second
+type ImagePosition struct {
+	URL            string `json:"url"`
+	ParagraphIndex int    `json:"paragraphIndex"`
 }
 
 // BlockedResponse represents when scraping is blocked
 type BlockedResponse struct {
 	Error    string   `json:"error"`
+	Code     string   `json:"code"`
 	Provider string   `json:"provider"`
 	Domain   string   `json:"domain"`
 	Metadata Metadata `json:"metadata"`
@@ -47,14 +153,106 @@ type BlockedResponse struct {
 // ErrorResponse represents error responses
 type ErrorResponse struct {
 	Error   string `json:"error"`
+	Code    string `json:"code"` // Machine-readable, one of the ErrCode* constants
 	Details string `json:"details,omitempty"`
 }
 
+// Machine-readable error codes returned in ErrorResponse.Code and BlockedResponse.Code
+const (
+	ErrCodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	ErrCodeUnauthorized     = "UNAUTHORIZED"
+	ErrCodeMissingURL       = "MISSING_URL"
+	ErrCodeInvalidURL       = "INVALID_URL"
+	ErrCodeInvalidBody      = "INVALID_BODY"
+	ErrCodeTimeout          = "TIMEOUT"
+	ErrCodeBlocked          = "BLOCKED"
+	ErrCodeLoginRequired    = "LOGIN_REQUIRED"
+	ErrCodeUpstreamError    = "UPSTREAM_ERROR"
+	ErrCodeUnextractable    = "UNEXTRACTABLE"
+)
+
 // Metadata contains request metadata
 type Metadata struct {
 	URL        string    `json:"url"`
+	FinalURL   string    `json:"finalUrl,omitempty"` // URL that yielded the content, after redirects or alternate fallback
 	ScrapedAt  time.Time `json:"scrapedAt"`
 	DurationMs int64     `json:"durationMs"`
+	PageCount  int       `json:"pageCount,omitempty"` // Pages merged when following pagination
+	Timing     *Timing   `json:"timing,omitempty"`    // Scrape phases making up DurationMs
+
+	// Upstream response status and allowlisted headers, when includeResponseInfo is set
+	StatusCode int               `json:"statusCode,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+
+	// Upstream body was cut at the size limit, so the content may be incomplete
+	BodyTruncated bool `json:"bodyTruncated,omitempty"`
+
+	// Encoding the upstream HTML was converted to UTF-8 from, e.g. "windows-1252"; HTTP
+	// fetches only, as the browser decodes pages itself
+	DetectedCharset string `json:"detectedCharset,omitempty"`
+
+	// HTTP redirect hops up to and including the final response, when includeRedirects is set
+	Redirects []RedirectHop `json:"redirects,omitempty"`
+
+	// Upstream cache validators, to send back as If-None-Match / If-Modified-Since
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Timing splits a scrape's duration into phases, in milliseconds. Rate limiting
+// waits count towards the fetch they precede.
+type Timing struct {
+	HTTPFetchMs  int64 `json:"httpFetchMs"`
+	BrowserMs    int64 `json:"browserMs"`    // 0 when the browser wasn't used
+	ExtractionMs int64 `json:"extractionMs"` // Includes merging paginated pages
+}
+
+// RedirectHop is one response in a redirect chain
+type RedirectHop struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"statusCode"`
+	DurationMs int64  `json:"durationMs"` // Time until this response's headers arrived
+}
+
+// Diagnostics reports the decisions behind an extraction, for debugging poor results
+type Diagnostics struct {
+	ExtractionMethod string         `json:"extractionMethod"`          // "readability", "fallback", "fullpage", "selector" or "pdf"
+	ContentSelector  string         `json:"contentSelector,omitempty"` // Selector the content came from; empty for readability
+	BrowserUsed      bool           `json:"browserUsed"`               // Content came from the browser rather than the HTTP fetch
+	ImageCandidates  int            `json:"imageCandidates"`           // Images found before filtering
+	ImagesKept       int            `json:"imagesKept"`                // Candidates passing the filters, before the top-N cut
+	ImageRejections  map[string]int `json:"imageRejections,omitempty"` // Rejected candidates per filter
+}
+
+// ValidateResponse reports a reachability check made without extracting content
+type ValidateResponse struct {
+	URL         string   `json:"url"`
+	FinalURL    string   `json:"finalUrl"` // After HTTP redirects
+	StatusCode  int      `json:"statusCode"`
+	ContentType string   `json:"contentType"`
+	IsHTML      bool     `json:"isHtml"`
+	Blocked     bool     `json:"blocked"` // Response looks like a bot wall
+	Metadata    Metadata `json:"metadata"`
+}
+
+// DiscoverResponse lists the feeds and sitemaps a site declares
+type DiscoverResponse struct {
+	URL      string   `json:"url"` // Site homepage that was inspected
+	Feeds    []string `json:"feeds"`
+	Sitemaps []string `json:"sitemaps"`
+	Metadata Metadata `json:"metadata"`
+
+	// Contents of the sitemaps, when expandSitemaps is set: page URLs, and the sitemaps
+	// listed by sitemap indexes
+	Pages         []string `json:"pages,omitempty"`
+	ChildSitemaps []string `json:"childSitemaps,omitempty"`
+}
+
+// VideoInfo describes a video embedded in the article
+type VideoInfo struct {
+	URL       string `json:"url"`
+	Provider  string `json:"provider"` // "youtube", "vimeo", "native" or "opengraph"
+	Thumbnail string `json:"thumbnail,omitempty"`
 }
 
 // ImageCandidate represents a potential image with scoring data
@@ -67,4 +265,5 @@ type ImageCandidate struct {
 	Source    string
 	Score     float64
 	Area      int
+	Index     int // Position in document order (-1 for meta tag sources)
 }
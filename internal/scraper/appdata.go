@@ -0,0 +1,130 @@
+package scraper
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AppDataArticle holds the article fields found in a JavaScript framework's inline state
+type AppDataArticle struct {
+	Title         string
+	Body          string // Plain text; HTML bodies are flattened like page content
+	DatePublished string
+}
+
+// ExtractAppData reads the state Next.js (__NEXT_DATA__) and Nuxt 2 (window.__NUXT__)
+// pages embed for hydration, which carries the article even when the served HTML is
+// an empty shell. The article is the object with a title key and the longest body of
+// at least AppDataMinBodyWords words. Nuxt state serialized as a function, rather than
+// a JSON literal, can't be read without running it and is skipped.
+func ExtractAppData(doc *goquery.Document) AppDataArticle {
+	var states []interface{}
+	doc.Find("script").Each(func(i int, s *goquery.Selection) {
+		raw := appStateJSON(s)
+		if raw == "" {
+			return
+		}
+
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(raw), &parsed); err == nil {
+			states = append(states, parsed)
+		}
+	})
+
+	var best AppDataArticle
+	bestWords := 0
+	for _, state := range states {
+		walkAppData(state, func(object map[string]interface{}) {
+			title := firstAppDataString(object, AppDataTitleKeys)
+			if title == "" {
+				return
+			}
+
+			body := ""
+			for _, key := range AppDataBodyKeys {
+				if text := appDataText(object[key]); len(text) > len(body) {
+					body = text
+				}
+			}
+			words := len(strings.Fields(body))
+			if words < AppDataMinBodyWords || words <= bestWords {
+				return
+			}
+
+			bestWords = words
+			best = AppDataArticle{
+				Title:         title,
+				Body:          body,
+				DatePublished: normalizeDate(firstAppDataString(object, AppDataDateKeys)),
+			}
+		})
+	}
+	return best
+}
+
+// appStateJSON returns the JSON of a framework state script, or "" for other scripts
+func appStateJSON(s *goquery.Selection) string {
+	if s.AttrOr("id", "") == "__NEXT_DATA__" {
+		return strings.TrimSpace(s.Text())
+	}
+
+	text := strings.TrimSpace(s.Text())
+	if !strings.HasPrefix(text, "window.__NUXT__") {
+		return ""
+	}
+	_, value, found := strings.Cut(text, "=")
+	value = strings.TrimSuffix(strings.TrimSpace(value), ";")
+	if !found || !strings.HasPrefix(value, "{") {
+		return ""
+	}
+	return value
+}
+
+// walkAppData calls visit for every object in a parsed JSON value, depth first
+func walkAppData(value interface{}, visit func(map[string]interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		visit(v)
+		for _, child := range v {
+			walkAppData(child, visit)
+		}
+	case []interface{}:
+		for _, child := range v {
+			walkAppData(child, visit)
+		}
+	}
+}
+
+// firstAppDataString returns the first non-empty string value among keys
+func firstAppDataString(object map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		if value, ok := object[key].(string); ok && strings.TrimSpace(value) != "" {
+			return CleanWhitespace(strings.TrimSpace(value))
+		}
+	}
+	return ""
+}
+
+// appDataText returns a body value as plain text, flattening HTML markup
+func appDataText(value interface{}) string {
+	raw, ok := value.(string)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return ""
+	}
+	if !strings.Contains(raw, "<") {
+		return CleanTextContent(raw)
+	}
+
+	fragment, err := goquery.NewDocumentFromReader(strings.NewReader(raw))
+	if err != nil {
+		return ""
+	}
+	body := fragment.Find("body")
+	text := ExtractTextFromElements(body, TextElements, false)
+	if text == "" {
+		text = ExtractFallbackText(body)
+	}
+	return CleanTextContent(text)
+}
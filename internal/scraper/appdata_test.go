@@ -0,0 +1,91 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+// appDataBody is a 60-word article body, long enough to pass AppDataMinBodyWords
+var appDataBody = strings.Repeat("The council approved the new transit plan after months of debate. ", 6)
+
+// nextShell is a Next.js page whose HTML is an empty shell around its __NEXT_DATA__
+func nextShell(data string) string {
+	return `<html><head><title>News Site</title></head><body><div id="__next"></div>
+<script id="__NEXT_DATA__" type="application/json">` + data + `</script></body></html>`
+}
+
+func TestExtractAppData(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		wantTitle string
+		wantBody  string
+		wantDate  string
+	}{
+		{
+			name:      "next data with html body",
+			html:      nextShell(`{"props":{"pageProps":{"article":{"title":"Transit Plan Approved","publishedAt":"2024-03-05T08:30:00Z","bodyHtml":"<p>` + appDataBody + `</p>"}}}}`),
+			wantTitle: "Transit Plan Approved",
+			wantBody:  strings.TrimSpace(appDataBody),
+			wantDate:  "2024-03-05T08:30:00Z",
+		},
+		{
+			name:      "nuxt state",
+			html:      `<html><body><script>window.__NUXT__={"data":[{"post":{"headline":"Transit Plan Approved","body":"` + appDataBody + `"}}]};</script></body></html>`,
+			wantTitle: "Transit Plan Approved",
+			wantBody:  strings.TrimSpace(appDataBody),
+		},
+		{
+			name: "nuxt function state skipped",
+			html: `<html><body><script>window.__NUXT__=(function(a){return {"post":{"title":"T","body":"` + appDataBody + `"}}}(1));</script></body></html>`,
+		},
+		{
+			name: "teaser too short",
+			html: nextShell(`{"props":{"pageProps":{"title":"Transit Plan Approved","body":"Read the full story."}}}`),
+		},
+		{
+			name: "no state",
+			html: `<html><body><p>Server-rendered page.</p></body></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractAppData(parseDoc(t, tt.html))
+			if got.Title != tt.wantTitle || got.Body != tt.wantBody || got.DatePublished != tt.wantDate {
+				t.Errorf("ExtractAppData = %+v, want %q, %q, %q", got, tt.wantTitle, tt.wantBody, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestUseAppData(t *testing.T) {
+	page := nextShell(`{"props":{"pageProps":{"post":{"title":"Transit Plan Approved","content":"` + appDataBody + `"}}}}`)
+
+	tests := []struct {
+		name       string
+		useAppData bool
+		wantTitle  string
+		wantBody   bool
+	}{
+		{"shell read from next data", true, "Transit Plan Approved", true},
+		{"off", false, "News Site", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.UseAppData = tt.useAppData
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if result.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", result.Title, tt.wantTitle)
+			}
+			if got := strings.Contains(result.Content, "new transit plan"); got != tt.wantBody {
+				t.Errorf("body in content = %v, want %v: %q", got, tt.wantBody, result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package scraper
+
+import (
+	"regexp"
+	"strings"
+
+	"extract-html-scraper/internal/config"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type AuthorExtractor struct {
+	regexes map[string]*regexp.Regexp
+}
+
+func NewAuthorExtractor() *AuthorExtractor {
+	return &AuthorExtractor{
+		regexes: config.CompileRegexes(),
+	}
+}
+
+// ExtractAuthors returns the article's authors from the first source that has any:
+// JSON-LD author fields, article:author meta tags, rel="author" links, then the byline
+// split on commas and conjunctions
+func (ae *AuthorExtractor) ExtractAuthors(doc *goquery.Document, byline string) []string {
+	if authors := ae.authorsFromJSONLD(doc); len(authors) > 0 {
+		return authors
+	}
+
+	var metaAuthors []string
+	doc.Find(`meta[property="article:author"], meta[name="article:author"]`).Each(func(i int, s *goquery.Selection) {
+		// Values are often profile URLs rather than names
+		if name := s.AttrOr("content", ""); !strings.Contains(name, "://") {
+			metaAuthors = append(metaAuthors, name)
+		}
+	})
+	if authors := dedupeNames(metaAuthors); len(authors) > 0 {
+		return authors
+	}
+
+	var linkAuthors []string
+	doc.Find(`a[rel~="author"]`).Each(func(i int, s *goquery.Selection) {
+		linkAuthors = append(linkAuthors, s.Text())
+	})
+	if authors := dedupeNames(linkAuthors); len(authors) > 0 {
+		return authors
+	}
+
+	return ae.SplitByline(byline)
+}
+
+// SplitByline splits a byline like "By Alice and Bob, with reporting by Carol" into names
+func (ae *AuthorExtractor) SplitByline(byline string) []string {
+	byline = ae.regexes["bylinePrefix"].ReplaceAllString(strings.TrimSpace(byline), "")
+	if byline == "" {
+		return nil
+	}
+	return dedupeNames(ae.regexes["bylineSplit"].Split(byline, -1))
+}
+
+// authorsFromJSONLD reads author names from the first JSON-LD object declaring authors
+func (ae *AuthorExtractor) authorsFromJSONLD(doc *goquery.Document) []string {
+	for _, object := range ExtractJSONLD(doc) {
+		author, ok := object["author"]
+		if !ok {
+			continue
+		}
+		if authors := dedupeNames(jsonLDNames(author)); len(authors) > 0 {
+			return authors
+		}
+	}
+	return nil
+}
+
+// ExtractAuthorImage returns the absolute URL of the first author's avatar, from the
+// JSON-LD author image, an image inside a rel="author" profile link, or one in the byline
+func (ae *AuthorExtractor) ExtractAuthorImage(doc *goquery.Document, baseURL string) string {
+	baseURL = ResolveBaseURL(doc, baseURL)
+
+	candidates := []string{ae.authorImageFromJSONLD(doc)}
+	doc.Find(`a[rel~="author"] img, ` + BylineSelectors).Each(func(i int, s *goquery.Selection) {
+		candidates = append(candidates, firstAttr(s, "src", "data-src", "data-lazy-src"))
+	})
+
+	for _, candidate := range candidates {
+		if candidate == "" || strings.HasPrefix(candidate, "data:") {
+			continue
+		}
+		if imageURL, err := ResolveURL(candidate, baseURL); err == nil && strings.HasPrefix(imageURL, "http") {
+			return imageURL
+		}
+	}
+	return ""
+}
+
+// authorImageFromJSONLD reads the first author's image from the first JSON-LD object declaring one
+func (ae *AuthorExtractor) authorImageFromJSONLD(doc *goquery.Document) string {
+	for _, object := range ExtractJSONLD(doc) {
+		author := object["author"]
+		if list, ok := author.([]interface{}); ok && len(list) > 0 {
+			author = list[0]
+		}
+		if person, ok := author.(map[string]interface{}); ok {
+			if image := jsonLDImageURL(person["image"]); image != "" {
+				return image
+			}
+		}
+	}
+	return ""
+}
+
+// jsonLDImageURL flattens a JSON-LD image value: a URL, an ImageObject, or a list of them
+func jsonLDImageURL(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		if url, ok := v["url"].(string); ok {
+			return strings.TrimSpace(url)
+		}
+		if id, ok := v["@id"].(string); ok {
+			return strings.TrimSpace(id)
+		}
+	case []interface{}:
+		for _, item := range v {
+			if url := jsonLDImageURL(item); url != "" {
+				return url
+			}
+		}
+	}
+	return ""
+}
+
+// jsonLDNames flattens a JSON-LD author value: a name, a Person/Organization, or a list of them
+func jsonLDNames(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			return []string{name}
+		}
+	case []interface{}:
+		var names []string
+		for _, item := range v {
+			names = append(names, jsonLDNames(item)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// dedupeNames trims names and drops empty and repeated (case-insensitive) entries
+func dedupeNames(names []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), SingleSpace)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package scraper
+
+import (
+	"testing"
+)
+
+func TestExtractAuthors(t *testing.T) {
+	tests := []struct {
+		name   string
+		html   string
+		byline string
+		want   []string
+	}{
+		{
+			name: "json-ld author list",
+			html: `<script type="application/ld+json">{"@type":"NewsArticle","author":[{"@type":"Person","name":"Alice Martin"},{"@type":"Person","name":"Bob Chen"}]}</script>`,
+			want: []string{"Alice Martin", "Bob Chen"},
+		},
+		{
+			name:   "json-ld wins over the byline",
+			html:   `<script type="application/ld+json">{"@type":"NewsArticle","author":{"@type":"Person","name":"Alice Martin"}}</script>`,
+			byline: "By Someone Else",
+			want:   []string{"Alice Martin"},
+		},
+		{
+			name: "article:author meta tags",
+			html: `<meta property="article:author" content="Alice Martin"><meta property="article:author" content="Bob Chen">`,
+			want: []string{"Alice Martin", "Bob Chen"},
+		},
+		{
+			name:   "byline split",
+			byline: "By Alice Martin and Bob Chen",
+			want:   []string{"Alice Martin", "Bob Chen"},
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<html><head>"+tt.html+"</head><body><p>Story.</p></body></html>")
+			if got := NewAuthorExtractor().ExtractAuthors(doc, tt.byline); !equalStrings(got, tt.want) {
+				t.Errorf("ExtractAuthors() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitByline(t *testing.T) {
+	tests := []struct {
+		byline string
+		want   []string
+	}{
+		{"By Alice Martin", []string{"Alice Martin"}},
+		{"Alice Martin, Bob Chen and Carol Diaz", []string{"Alice Martin", "Bob Chen", "Carol Diaz"}},
+		{"Alice Martin & alice martin", []string{"Alice Martin"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.byline, func(t *testing.T) {
+			if got := NewAuthorExtractor().SplitByline(tt.byline); !equalStrings(got, tt.want) {
+				t.Errorf("SplitByline(%q) = %q, want %q", tt.byline, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractAuthorImage(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "json-ld image object",
+			html: `<script type="application/ld+json">{"@type":"NewsArticle","author":[{"@type":"Person","name":"Alice Martin","image":{"@type":"ImageObject","url":"https://cdn.example.com/alice.jpg"}}]}</script>`,
+			want: "https://cdn.example.com/alice.jpg",
+		},
+		{
+			name: "author profile link",
+			html: `<a rel="author" href="/authors/alice"><img src="/avatars/alice.png" alt="Alice"></a>`,
+			want: "https://example.com/avatars/alice.png",
+		},
+		{
+			name: "byline avatar",
+			html: `<div class="byline"><img data-src="//cdn.example.com/bob.jpg"> By Bob Chen</div>`,
+			want: "https://cdn.example.com/bob.jpg",
+		},
+		{
+			name: "data uri placeholder skipped",
+			html: `<div class="byline"><img src="data:image/gif;base64,R0lGOD"> By Bob Chen</div>`,
+		},
+		{
+			name: "none",
+			html: `<p>By Alice Martin</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<html><body>"+tt.html+"</body></html>")
+			if got := NewAuthorExtractor().ExtractAuthorImage(doc, "https://example.com/news/a"); got != tt.want {
+				t.Errorf("ExtractAuthorImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package scraper
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractBreadcrumbs returns the page's breadcrumb trail, broadest first: the names of a
+// JSON-LD BreadcrumbList ordered by position, else the entries of a breadcrumb nav, or
+// nil when the page has neither. The last entry may be the page itself.
+func ExtractBreadcrumbs(doc *goquery.Document) []string {
+	for _, object := range ExtractJSONLD(doc) {
+		if jsonLDHasType(object, "BreadcrumbList") {
+			if crumbs := breadcrumbsFromJSONLD(object); len(crumbs) > 0 {
+				return crumbs
+			}
+		}
+	}
+	return breadcrumbsFromNav(doc)
+}
+
+// breadcrumbsFromJSONLD reads a BreadcrumbList's ListItems, naming each by its own name or
+// its item's, and orders them by position; items without one keep their list order last
+func breadcrumbsFromJSONLD(object map[string]interface{}) []string {
+	type crumb struct {
+		name     string
+		position float64
+	}
+
+	items, _ := object["itemListElement"].([]interface{})
+	var crumbs []crumb
+	for i, value := range items {
+		item, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := jsonLDFirstString(item["name"])
+		if nested, ok := item["item"].(map[string]interface{}); ok && name == "" {
+			name = jsonLDFirstString(nested["name"])
+		}
+		if name = CleanWhitespace(name); name == "" {
+			continue
+		}
+
+		position, err := strconv.ParseFloat(jsonLDFirstString(item["position"]), 64)
+		if err != nil {
+			position = float64(len(items) + i)
+		}
+		crumbs = append(crumbs, crumb{name: name, position: position})
+	}
+
+	sort.SliceStable(crumbs, func(i, j int) bool {
+		return crumbs[i].position < crumbs[j].position
+	})
+
+	var names []string
+	for _, c := range crumbs {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// breadcrumbsFromNav reads the first breadcrumb block's list items, or its links when it
+// has no list, skipping separators
+func breadcrumbsFromNav(doc *goquery.Document) []string {
+	nav := doc.Find(BreadcrumbSelectors).First()
+	if nav.Length() == 0 {
+		return nil
+	}
+
+	entries := nav.Find("li")
+	if entries.Length() == 0 {
+		entries = nav.Find("a")
+	}
+
+	var names []string
+	entries.Each(func(i int, s *goquery.Selection) {
+		// Nested lists hold their own entries
+		if s.Find("li").Length() > 0 {
+			return
+		}
+		name := strings.Trim(CleanWhitespace(strings.Join(strings.Fields(s.Text()), SingleSpace)), BreadcrumbSeparators)
+		if name != "" {
+			names = append(names, name)
+		}
+	})
+	return names
+}
@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+// breadcrumbJSONLD is a three-level BreadcrumbList listed out of position order
+const breadcrumbJSONLD = `<script type="application/ld+json">{"@context":"https://schema.org","@type":"BreadcrumbList","itemListElement":[
+{"@type":"ListItem","position":3,"name":"Transit","item":"https://example.com/news/local/transit"},
+{"@type":"ListItem","position":1,"item":{"@id":"https://example.com/news","name":"News"}},
+{"@type":"ListItem","position":"2","name":"Local","item":"https://example.com/news/local"}]}</script>`
+
+func TestExtractBreadcrumbs(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []string
+	}{
+		{
+			name: "json-ld ordered by position",
+			html: `<html><head>` + breadcrumbJSONLD + `</head><body></body></html>`,
+			want: []string{"News", "Local", "Transit"},
+		},
+		{
+			name: "json-ld in a graph",
+			html: `<html><head><script type="application/ld+json">{"@graph":[{"@type":"NewsArticle","headline":"Transit Plan Approved"},
+{"@type":"BreadcrumbList","itemListElement":[{"@type":"ListItem","position":1,"name":"News"},{"@type":"ListItem","position":2,"name":"Local"}]}]}</script></head><body></body></html>`,
+			want: []string{"News", "Local"},
+		},
+		{
+			name: "json-ld preferred over nav",
+			html: `<html><head>` + breadcrumbJSONLD + `</head><body><nav aria-label="breadcrumb"><a href="/">Home</a></nav></body></html>`,
+			want: []string{"News", "Local", "Transit"},
+		},
+		{
+			name: "nav list",
+			html: `<html><body><nav aria-label="breadcrumb"><ol><li><a href="/news">News</a> /</li><li><a href="/news/local">Local</a> ›</li><li>Transit</li></ol></nav></body></html>`,
+			want: []string{"News", "Local", "Transit"},
+		},
+		{
+			name: "nav links with separators",
+			html: `<html><body><div class="breadcrumbs"><a href="/news">News</a> » <a href="/news/local">Local</a></div></body></html>`,
+			want: []string{"News", "Local"},
+		},
+		{
+			name: "none",
+			html: `<html><body><p>` + strings.Repeat("No trail here. ", 5) + `</p></body></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractBreadcrumbs(parseDoc(t, tt.html)); !equalStrings(got, tt.want) {
+				t.Errorf("ExtractBreadcrumbs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludeBreadcrumbs(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "</head>", breadcrumbJSONLD+"</head>", 1)
+
+	tests := []struct {
+		name    string
+		include bool
+		want    []string
+	}{
+		{"off", false, nil},
+		{"three levels", true, []string{"News", "Local", "Transit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeBreadcrumbs = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/local/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if !equalStrings(result.Breadcrumbs, tt.want) {
+				t.Errorf("Breadcrumbs = %q, want %q", result.Breadcrumbs, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package scraper
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"time"
@@ -29,20 +30,41 @@ func NewBrowserClient() *BrowserClient {
 
 // ScrapeWithBrowser uses chromedp to scrape content with fallback to alternate URLs
 func (b *BrowserClient) ScrapeWithBrowser(ctx context.Context, targetURL string, timeoutMs int) (string, string, error) {
-	opts := DefaultBrowserOptions()
-	opts.UserAgent = b.config.UserAgent
-	return b.scrapeWithOptions(ctx, targetURL, timeoutMs, opts)
+	result, err := b.ScrapeWithBrowserOptions(ctx, targetURL, timeoutMs, DefaultBrowserOptions())
+	if err != nil {
+		return "", "", err
+	}
+	return result.HTML, result.URL, nil
 }
 
 // ScrapeWithBrowserOptimized is an optimized version that blocks more resources
 func (b *BrowserClient) ScrapeWithBrowserOptimized(ctx context.Context, targetURL string, timeoutMs int) (string, string, error) {
-	opts := OptimizedBrowserOptions()
-	opts.UserAgent = b.config.UserAgent
+	result, err := b.ScrapeWithBrowserOptions(ctx, targetURL, timeoutMs, OptimizedBrowserOptions())
+	if err != nil {
+		return "", "", err
+	}
+	return result.HTML, result.URL, nil
+}
+
+// ScrapeWithBrowserOptions scrapes with the given browser options, reporting the
+// navigation response status and headers along with the rendered HTML
+func (b *BrowserClient) ScrapeWithBrowserOptions(ctx context.Context, targetURL string, timeoutMs int, opts BrowserOptions) (*FetchResult, error) {
+	if opts.UserAgent == "" {
+		opts.UserAgent = b.config.UserAgent
+	}
+	if opts.ExecPath == "" {
+		opts.ExecPath = b.config.ChromePath
+	}
+	if len(opts.ExtraFlags) == 0 {
+		opts.ExtraFlags = b.config.ChromeExtraFlags
+	}
+	opts.NoAlternates = opts.NoAlternates || b.config.DisableAlternates
+	opts.Headers = CleanRequestHeaders(opts.Headers, false)
 	return b.scrapeWithOptions(ctx, targetURL, timeoutMs, opts)
 }
 
 // scrapeWithOptions is the unified scraping function using browser options
-func (b *BrowserClient) scrapeWithOptions(ctx context.Context, targetURL string, timeoutMs int, opts BrowserOptions) (string, string, error) {
+func (b *BrowserClient) scrapeWithOptions(ctx context.Context, targetURL string, timeoutMs int, opts BrowserOptions) (*FetchResult, error) {
 	// Create a new context with timeout
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
 	defer cancel()
@@ -57,6 +79,18 @@ func (b *BrowserClient) scrapeWithOptions(ctx context.Context, targetURL string,
 	ctx, cancel = chromedp.NewContext(allocCtx)
 	defer cancel()
 
+	// Emulate the requested device or viewport
+	if err := chromedp.Run(ctx, EmulationAction(opts)); err != nil {
+		return nil, fmt.Errorf("failed to set up emulation: %w", err)
+	}
+
+	// Send the custom headers to the target's site only
+	if len(opts.Headers) > 0 {
+		if err := chromedp.Run(ctx, CustomHeadersAction(targetURL, opts.Headers)); err != nil {
+			return nil, fmt.Errorf("failed to set up custom headers: %w", err)
+		}
+	}
+
 	// Set up request blocking
 	err := chromedp.Run(ctx, chromedp.Tasks{
 		chromedp.ActionFunc(func(ctx context.Context) error {
@@ -66,43 +100,61 @@ func (b *BrowserClient) scrapeWithOptions(ctx context.Context, targetURL string,
 		}),
 	})
 	if err != nil {
-		return "", "", fmt.Errorf("failed to set up request blocking: %w", err)
+		return nil, fmt.Errorf("failed to set up request blocking: %w", err)
 	}
 
 	// Try primary URL first
-	html, finalURL, err := b.navigateAndExtract(ctx, targetURL)
-	if err == nil && !b.LooksLikeCFBlock(html) {
-		return html, finalURL, nil
+	result, err := b.navigateAndExtract(ctx, targetURL, opts)
+	if err == nil && !b.LooksLikeCFBlock(result.HTML) {
+		return result, nil
+	}
+
+	if opts.NoAlternates {
+		if err == nil {
+			err = fmt.Errorf("blocked by Cloudflare")
+		}
+		return nil, err
 	}
 
 	// Generate alternate URLs and try them
 	alternates, err := b.GenerateAlternateURLs(targetURL)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
 	for _, altURL := range alternates {
-		html, finalURL, err := b.navigateAndExtract(ctx, altURL)
-		if err == nil && !b.LooksLikeCFBlock(html) {
-			return html, finalURL, nil
+		result, err := b.navigateAndExtract(ctx, altURL, opts)
+		if err == nil && !b.LooksLikeCFBlock(result.HTML) && !LooksLikeSoft404(result.HTML, b.regexes["soft404"]) {
+			return result, nil
 		}
 	}
 
-	return "", "", fmt.Errorf("all URLs failed or were blocked by Cloudflare")
+	return nil, fmt.Errorf("all URLs failed or were blocked by Cloudflare")
 }
 
 // navigateAndExtract navigates to a URL and extracts HTML content
-func (b *BrowserClient) navigateAndExtract(ctx context.Context, targetURL string) (string, string, error) {
+func (b *BrowserClient) navigateAndExtract(ctx context.Context, targetURL string, opts BrowserOptions) (*FetchResult, error) {
 	var html string
 	var finalURL string
 
-	err := chromedp.Run(ctx, chromedp.Tasks{
-		// Navigate to the URL
-		chromedp.Navigate(targetURL),
+	// Navigate to the URL, keeping the main document response
+	resp, err := chromedp.RunResponse(ctx, chromedp.Navigate(targetURL))
+	if err != nil {
+		return nil, fmt.Errorf("navigation failed: %w", err)
+	}
+
+	// Get past cookie-consent modals that defer the article; failures leave the page as is
+	if opts.DismissConsent {
+		b.dismissConsent(ctx)
+	}
 
+	err = chromedp.Run(ctx, chromedp.Tasks{
 		// Wait for network to be idle
 		chromedp.WaitReady("body"),
 
+		// Give client-side hydration time to render; Sleep stops at the deadline
+		chromedp.Sleep(opts.SettleDelay),
+
 		// Get the final URL after redirects
 		chromedp.Location(&finalURL),
 
@@ -111,10 +163,38 @@ func (b *BrowserClient) navigateAndExtract(ctx context.Context, targetURL string
 	})
 
 	if err != nil {
-		return "", "", fmt.Errorf("navigation failed: %w", err)
+		return nil, fmt.Errorf("navigation failed: %w", err)
+	}
+
+	result := &FetchResult{
+		HTML:   html,
+		URL:    finalURL,
+		Header: http.Header{},
+	}
+	if resp != nil {
+		result.StatusCode = int(resp.Status)
+		for name, value := range resp.Headers {
+			result.Header.Set(name, fmt.Sprint(value))
+		}
+	}
+
+	return result, nil
+}
+
+// dismissConsent clicks a consent modal's accept button once the body is ready, then
+// gives the page ConsentSettleDelay to reveal the content; bounded by ctx's deadline
+func (b *BrowserClient) dismissConsent(ctx context.Context) {
+	var clicked bool
+	err := chromedp.Run(ctx,
+		chromedp.WaitReady("body"),
+		chromedp.Evaluate(ConsentDismissScript, &clicked),
+	)
+	if err != nil || !clicked {
+		return
 	}
 
-	return html, finalURL, nil
+	LoggerFromContext(ctx).Info("dismissed consent modal", "path", PathBrowser)
+	_ = chromedp.Run(ctx, chromedp.Sleep(ConsentSettleDelay))
 }
 
 // LooksLikeCFBlock checks if HTML content indicates Cloudflare blocking
@@ -131,6 +211,6 @@ func (b *BrowserClient) GenerateAlternateURLs(originalURL string) ([]string, err
 }
 
 // navigateAndExtractOptimized uses domcontentloaded for faster loading
-func (b *BrowserClient) navigateAndExtractOptimized(ctx context.Context, targetURL string) (string, string, error) {
-	return b.navigateAndExtract(ctx, targetURL)
+func (b *BrowserClient) navigateAndExtractOptimized(ctx context.Context, targetURL string) (*FetchResult, error) {
+	return b.navigateAndExtract(ctx, targetURL, OptimizedBrowserOptions())
 }
@@ -2,7 +2,18 @@
 package scraper
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
+	"github.com/chromedp/chromedp/device"
 )
 
 // BrowserOptions contains configuration for browser automation
@@ -15,6 +26,29 @@ type BrowserOptions struct {
 	WindowWidth  int
 	WindowHeight int
 	UserAgent    string
+	Device       string        // DeviceDesktop or DeviceMobile
+	SettleDelay  time.Duration // Extra wait after the body is ready, for late hydration
+
+	// AcceptLanguage is sent with every request and its first language sets the
+	// page locale (empty keeps Chrome's defaults)
+	AcceptLanguage string
+
+	// Referer is sent with every request (empty sends Chrome's own)
+	Referer string
+
+	// Headers are sent with requests to the target's site only, see CustomHeadersAction
+	Headers map[string]string
+
+	// DismissConsent clicks the accept button of a cookie-consent modal before capturing HTML
+	DismissConsent bool
+
+	// NoAlternates fails instead of rendering AMP/mobile alternates of a blocked URL
+	NoAlternates bool
+
+	// ExecPath is the Chrome binary (empty autodetects it) and ExtraFlags are appended
+	// launch flags, as "--name" or "--name=value"
+	ExecPath   string
+	ExtraFlags []string
 }
 
 // DefaultBrowserOptions returns standard browser options
@@ -55,11 +89,21 @@ func BuildChromeOptions(opts BrowserOptions) []chromedp.ExecAllocatorOption {
 		chromedp.WindowSize(opts.WindowWidth, opts.WindowHeight),
 	)
 
+	// Custom Docker images may install Chrome outside the autodetected paths
+	if opts.ExecPath != "" {
+		chromeOpts = append(chromeOpts, chromedp.ExecPath(opts.ExecPath))
+	}
+
 	// Add user agent if provided
 	if opts.UserAgent != "" {
 		chromeOpts = append(chromeOpts, chromedp.UserAgent(opts.UserAgent))
 	}
 
+	// navigator.languages follows the requested languages
+	if opts.AcceptLanguage != "" {
+		chromeOpts = append(chromeOpts, chromedp.Flag("accept-lang", opts.AcceptLanguage))
+	}
+
 	// Add optimization flags
 	if opts.Optimized {
 		if opts.BlockImages {
@@ -74,9 +118,119 @@ func BuildChromeOptions(opts BrowserOptions) []chromedp.ExecAllocatorOption {
 		)
 	}
 
+	// Extra flags come last so they can override the ones above
+	for _, flag := range opts.ExtraFlags {
+		if name, value := parseChromeFlag(flag); name != "" {
+			chromeOpts = append(chromeOpts, chromedp.Flag(name, value))
+		}
+	}
+
 	return chromeOpts
 }
 
+// parseChromeFlag splits a "--name=value" launch flag; a bare "--name" is a boolean flag
+func parseChromeFlag(flag string) (string, interface{}) {
+	flag = strings.TrimLeft(flag, "-")
+	if name, value, found := strings.Cut(flag, "="); found {
+		return name, value
+	}
+	return flag, true
+}
+
+// EmulationAction emulates the requested device, or applies the window size as the
+// viewport, along with the requested languages and referer
+func EmulationAction(opts BrowserOptions) chromedp.Action {
+	var tasks chromedp.Tasks
+	if opts.Device == DeviceMobile {
+		// Mobile UA, viewport, device scale and touch
+		tasks = append(tasks, chromedp.Emulate(device.Pixel5))
+	} else {
+		tasks = append(tasks, chromedp.EmulateViewport(int64(opts.WindowWidth), int64(opts.WindowHeight)))
+	}
+
+	// Extra headers are set in one call, as each call replaces the previous ones
+	headers := network.Headers{}
+	if opts.AcceptLanguage != "" {
+		headers["Accept-Language"] = opts.AcceptLanguage
+		tasks = append(tasks, emulation.SetLocaleOverride().WithLocale(primaryLocale(opts.AcceptLanguage)))
+	}
+	if opts.Referer != "" {
+		headers["Referer"] = opts.Referer
+	}
+	if len(headers) > 0 {
+		tasks = append(tasks, network.SetExtraHTTPHeaders(headers))
+	}
+	return tasks
+}
+
+// CustomHeadersAction pauses the requests to targetURL's site, including its www, mobile
+// and AMP hosts, to set the custom headers over the browser's, so third-party requests
+// never see them. Host can't be set in the browser.
+func CustomHeadersAction(targetURL string, headers map[string]string) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		chromedp.ListenTarget(ctx, func(ev interface{}) {
+			paused, ok := ev.(*fetch.EventRequestPaused)
+			if !ok {
+				return
+			}
+			go func() {
+				_ = fetch.ContinueRequest(paused.RequestID).
+					WithHeaders(mergeHeaderEntries(paused.Request.Headers, headers)).
+					Do(ctx)
+			}()
+		})
+		return fetch.Enable().WithPatterns(sitePatterns(targetURL)).Do(ctx)
+	})
+}
+
+// sitePatterns matches the URLs of a site's host and its alternate hosts, on any port
+func sitePatterns(targetURL string) []*fetch.RequestPattern {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return nil
+	}
+
+	host := siteHost(strings.ToLower(parsed.Hostname()))
+	hosts := []string{host}
+	for _, prefix := range AlternateHostPrefixes {
+		hosts = append(hosts, prefix+host)
+	}
+
+	var patterns []*fetch.RequestPattern
+	for _, h := range hosts {
+		patterns = append(patterns,
+			&fetch.RequestPattern{URLPattern: "*://" + h + "/*"},
+			&fetch.RequestPattern{URLPattern: "*://" + h + ":*/*"},
+		)
+	}
+	return patterns
+}
+
+// mergeHeaderEntries sets the custom headers over a paused request's own, matching
+// names case-insensitively
+func mergeHeaderEntries(requestHeaders network.Headers, custom map[string]string) []*fetch.HeaderEntry {
+	var entries []*fetch.HeaderEntry
+	for name, value := range requestHeaders {
+		if _, overridden := custom[http.CanonicalHeaderKey(name)]; overridden {
+			continue
+		}
+		entries = append(entries, &fetch.HeaderEntry{Name: name, Value: fmt.Sprint(value)})
+	}
+	for name, value := range custom {
+		if name != "Host" {
+			entries = append(entries, &fetch.HeaderEntry{Name: name, Value: value})
+		}
+	}
+	return entries
+}
+
+// primaryLocale turns the first Accept-Language entry into an ICU locale, "fr-FR" to "fr_FR"
+func primaryLocale(acceptLanguage string) string {
+	first, _, _ := strings.Cut(acceptLanguage, ",")
+	first, _, _ = strings.Cut(first, ";")
+	return strings.ReplaceAll(strings.TrimSpace(first), "-", "_")
+}
+
 // GetRequestBlockingScript returns JavaScript for blocking unwanted requests
 func GetRequestBlockingScript(opts BrowserOptions) string {
 	script := `
@@ -130,3 +284,29 @@ func GetRequestBlockingScript(opts BrowserOptions) string {
 
 	return script
 }
+
+// ConsentDismissScript clicks the first visible accept button of a cookie-consent modal,
+// by known consent-platform selectors, then by button text. It evaluates to whether a
+// button was clicked. Consent frames from another origin are out of reach.
+const ConsentDismissScript = `
+	(() => {
+		const selectors = [
+			'#onetrust-accept-btn-handler', '#didomi-notice-agree-button',
+			'#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll', '#CybotCookiebotDialogBodyButtonAccept',
+			'.fc-cta-consent', '#truste-consent-button', '.qc-cmp2-summary-buttons button[mode="primary"]',
+			'[data-testid="uc-accept-all-button"]', '.cmplz-accept', '#cookie-accept', '.cc-allow', '.cc-accept'
+		];
+		const labels = /^\s*(accept( all| all cookies| cookies)?|allow( all)?( cookies)?|i agree|agree( and (continue|close))?|got it|ok(ay)?|tout accepter|accepter|alle akzeptieren|akzeptieren|aceptar( todo)?|accetta( tutto)?|aceitar( tudo)?|alles accepteren)\s*$/i;
+		const visible = el => !!(el.offsetWidth || el.offsetHeight || el.getClientRects().length);
+
+		for (const selector of selectors) {
+			const el = document.querySelector(selector);
+			if (el && visible(el)) { el.click(); return true; }
+		}
+		for (const el of document.querySelectorAll('button, [role="button"], a[href="#"], input[type="button"], input[type="submit"]')) {
+			const text = el.innerText || el.value || '';
+			if (labels.test(text) && visible(el)) { el.click(); return true; }
+		}
+		return false;
+	})()
+`
@@ -0,0 +1,53 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// consentPage shows only a consent modal; accepting it renders the article
+const consentPage = `<html><head><title>Consent Required</title></head><body>
+<div id="onetrust-banner-sdk"><p>We value your privacy.</p>
+<button id="onetrust-accept-btn-handler" onclick="document.getElementById('content').innerHTML = '<p>The council approved the new transit plan on Tuesday.</p>'; this.parentNode.remove()">Accept all</button></div>
+<main id="content"></main>
+</body></html>`
+
+func TestDismissConsent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, consentPage)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		dismiss     bool
+		wantArticle bool
+	}{
+		{"consent modal left", false, false},
+		{"consent accepted", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.DismissConsent = tt.dismiss
+			opts := options.browserOptions()
+			if opts.DismissConsent != tt.dismiss {
+				t.Fatalf("browser DismissConsent = %v, want %v", opts.DismissConsent, tt.dismiss)
+			}
+
+			result, err := NewBrowserClient().ScrapeWithBrowserOptions(context.Background(), server.URL, 15000, opts)
+			if err != nil {
+				t.Skipf("browser unavailable: %v", err)
+			}
+			if got := strings.Contains(result.HTML, "new transit plan"); got != tt.wantArticle {
+				t.Errorf("article rendered = %v, want %v", got, tt.wantArticle)
+			}
+		})
+	}
+}
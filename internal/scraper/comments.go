@@ -0,0 +1,38 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractComments returns the text of each reader comment in the page's comments
+// section, or nil when it has none. Comments are the innermost comment bodies, so
+// replies come out as their own entries; a section without recognizable comment
+// markup yields its whole text as one entry.
+func ExtractComments(doc *goquery.Document) []string {
+	section := doc.Find(CommentSectionSelectors).First()
+	if section.Length() == 0 {
+		return nil
+	}
+	section = section.Clone()
+	section.Find(NonContentTags + ", form, textarea, button").Remove()
+
+	var comments []string
+	section.Find(CommentBodySelectors).Each(func(i int, s *goquery.Selection) {
+		if s.Find(CommentBodySelectors).Length() > 0 {
+			return
+		}
+		if text := CleanWhitespace(strings.Join(strings.Fields(s.Text()), SingleSpace)); text != "" {
+			comments = append(comments, text)
+		}
+	})
+	if len(comments) > 0 {
+		return comments
+	}
+
+	if text := CleanWhitespace(strings.Join(strings.Fields(section.Text()), SingleSpace)); text != "" {
+		return []string{text}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+// commentsBlock is a threaded comments section with one reply
+const commentsBlock = `<section id="comments"><h2>Comments</h2>
+<ol class="comment-list">
+<li class="comment"><div class="comment-content"><p>Finally some real bus lanes for this city.</p></div>
+<ol class="children"><li class="comment"><div class="comment-content"><p>Agreed, long overdue.</p></div></li></ol>
+</li>
+</ol>
+<form><textarea>Leave a reply</textarea><button>Post</button></form>
+</section>`
+
+func TestExtractComments(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []string
+	}{
+		{"no comments section", `<html><body><article><p>Just the story.</p></article></body></html>`, nil},
+		{"threaded comments", `<html><body>` + commentsBlock + `</body></html>`, []string{"Finally some real bus lanes for this city.", "Agreed, long overdue."}},
+		{"section without comment markup", `<html><body><div id="disqus_thread"><p>First!</p>
+<p>Great read.</p></div></body></html>`, []string{"First! Great read."}},
+		{"empty section", `<html><body><div class="comments"><button>Load comments</button></div></body></html>`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractComments(parseDoc(t, tt.html)); !equalStrings(got, tt.want) {
+				t.Errorf("ExtractComments = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludeComments(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "</article>", commentsBlock+"</article>", 1)
+
+	tests := []struct {
+		name         string
+		page         string
+		include      bool
+		wantComments int
+	}{
+		{"off", page, false, 0},
+		{"comments returned separately", page, true, 2},
+		{"page without comments", multiSectionPage, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeComments = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(tt.page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if len(result.Comments) != tt.wantComments {
+				t.Fatalf("comments = %q, want %d", result.Comments, tt.wantComments)
+			}
+			if !strings.Contains(result.Content, "first bus lanes") {
+				t.Errorf("content misses the article body: %q", result.Content)
+			}
+			if tt.include && strings.Contains(result.Content, "long overdue") {
+				t.Errorf("comments leaked into content: %q", result.Content)
+			}
+		})
+	}
+}
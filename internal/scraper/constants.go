@@ -12,9 +12,83 @@ const (
 
 // Content extraction selectors
 const (
-	ContentSelectors = "article, main, [role='main'], .content, .post-content, .entry-content, .article-content, .story-content"
-	TextElements     = "p, h1, h2, h3, h4, h5, h6, li, blockquote"
-	NonContentTags   = "script, style, nav, header, footer"
+	ContentSelectors    = "article, main, [role='main'], .content, .post-content, .entry-content, .article-content, .story-content"
+	TextElements        = "p, h1, h2, h3, h4, h5, h6, li, blockquote"
+	NonContentTags      = "script, style, nav, header, footer"
+	HeadingTags         = "h1, h2, h3, h4, h5, h6"
+	ParagraphElements   = "p, li, blockquote"
+	ImageTags           = "img, amp-img"
+	SeriesNavSelectors  = ".post-navigation, .nav-links, nav.navigation, .post-nav, .article-nav"
+	PaginationSelectors = ".pagination, .pager, .page-numbers, .pages, nav[aria-label='pagination'], nav[aria-label='Pagination']"
+)
+
+// BylineSelectors match author avatars in byline blocks
+const BylineSelectors = `.byline img, .author img, [class*="author-avatar"] img, img[class*="author"], [itemprop="author"] img, [rel~="author"] img`
+
+// Quote blocks returned in Quotes; social embeds built on <blockquote> are skipped
+const (
+	QuoteSelectors      = `blockquote, [class*="pullquote"], [class*="pull-quote"]`
+	QuoteEmbedSelectors = ".twitter-tweet, .instagram-media, .tiktok-embed, .reddit-embed-bq"
+)
+
+// Breadcrumb blocks, see ExtractBreadcrumbs, and the separator characters trimmed off
+// their entries
+const (
+	BreadcrumbSelectors  = `[itemtype*="schema.org/BreadcrumbList"], nav[aria-label="breadcrumb"], nav[aria-label="Breadcrumb"], nav[aria-label="breadcrumbs"], nav[aria-label="Breadcrumbs"], .breadcrumb, .breadcrumbs, #breadcrumbs, #breadcrumb, [class*="breadcrumb"]`
+	BreadcrumbSeparators = "/>›»|·• \u00a0"
+)
+
+// Table of contents blocks, see ExtractTOC; a candidate needs this many in-page links
+const (
+	TOCSelectors  = `.toc, #toc, .table-of-contents, #table-of-contents, [class*="toc-container"], [role="doc-toc"], nav[role]`
+	TOCMinAnchors = 2
+)
+
+// Reader comment sections and the comment bodies inside them
+const (
+	CommentSectionSelectors = "#comments, .comments, #disqus_thread, .comment-list, .commentlist, #respond-comments, [class*='comments-area'], [id*='comments-section'], section[aria-label='Comments']"
+	CommentBodySelectors    = ".comment-content, .comment-body, .comment-text, [itemprop='comment'] [itemprop='text'], li.comment, article.comment, .comment"
+)
+
+// Content extraction methods
+const (
+	ExtractionMethodReadability = "readability"
+	ExtractionMethodFallback    = "fallback"
+	ExtractionMethodFullPage    = "fullpage"
+	ExtractionMethodSelector    = "selector"
+	ExtractionMethodPDF         = "pdf"
+	ExtractionMethodAppData     = "appdata"
+	ExtractionMethodTemplate    = "template"
+)
+
+// Inline framework state (__NEXT_DATA__, __NUXT__) read when the HTML is a shell
+const (
+	AppDataMaxShellWords = 50 // extracted content this short counts as a shell
+	AppDataMinBodyWords  = 50 // shorter bodies are teasers or UI strings
+)
+
+// Inert markup a script clones into the page: <template> elements, except declarative
+// shadow roots which the browser renders, and HTML kept in script templates
+const TemplateSelectors = `template:not([shadowrootmode]):not([shadowroot]), script[type="text/template"], script[type="text/x-template"], script[type="text/html"]`
+
+// Template content shorter than this is UI markup rather than an article
+const TemplateMinWords = 50
+
+// Keys naming an article's fields in inline framework state, in order of preference
+var (
+	AppDataTitleKeys = []string{"headline", "title"}
+	AppDataBodyKeys  = []string{"articleBody", "body", "bodyHtml", "content", "contentHtml", "html", "text"}
+	AppDataDateKeys  = []string{"datePublished", "publishedAt", "published_at", "publishDate", "publishedDate", "firstPublishedAt", "date", "createdAt"}
+)
+
+// Title sources reported in TitleCandidates
+const (
+	TitleSourceOpenGraph = "og:title"
+	TitleSourceTwitter   = "twitter:title"
+	TitleSourceHeading   = "h1"
+	TitleSourceTitleTag  = "title"
+	TitleSourceMicrodata = "microdata"
+	TitleSourceAppData   = "appdata"
 )
 
 // Meta tag properties
@@ -25,11 +99,25 @@ const (
 	OGImageSecure = "og:image:secure_url"
 	OGImageWidth  = "og:image:width"
 	OGImageHeight = "og:image:height"
+	OGImageAlt    = "og:image:alt"
+	OGVideo       = "og:video"
+	OGVideoURL    = "og:video:url"
+	OGVideoSecure = "og:video:secure_url"
+	OGLocale      = "og:locale"
+	OGLocaleAlt   = "og:locale:alternate"
 	TwitterTitle  = "twitter:title"
 	TwitterDesc   = "twitter:description"
+	TwitterAlt    = "twitter:image:alt"
 	MetaDesc      = "description"
+	MetaTheme     = "theme-color"
 )
 
+// SlugMaxLength caps Slug in bytes
+const SlugMaxLength = 80
+
+// MinContentLineLength is the longest content line dropped as UI noise
+const MinContentLineLength = 20
+
 // Text processing constants
 const (
 	DoubleNewline = "\n\n"
@@ -44,6 +132,194 @@ const (
 	DefaultWindowWidth  = 1366
 	DefaultWindowHeight = 900
 	MaxRedirects        = 5
+	MaxSettleDelay      = 10 * time.Second // Upper bound for per-request settle delays
+	ConsentSettleDelay  = time.Second      // Wait after accepting a consent modal
+)
+
+// ScrapeRetryDelay separates whole-scrape attempts, see SCRAPE_MAX_ATTEMPTS
+const ScrapeRetryDelay = 2 * time.Second
+
+// Blank browser render retries
+const (
+	DefaultBlankRetries   = 1
+	MaxBlankRetries       = 3
+	BlankRetrySettleDelay = 2 * time.Second // Minimum settle delay when rendering again
+)
+
+// DefaultAcceptLanguage is sent unless a request asks for other languages
+const DefaultAcceptLanguage = "en-US,en;q=0.9"
+
+// DefaultReferer is sent unless a request sets its own Referer, or none
+const DefaultReferer = "https://www.google.com/"
+
+// Custom request headers are capped in number and value length
+const (
+	MaxCustomHeaders     = 20
+	MaxCustomHeaderBytes = 4096
+)
+
+// BlockedRequestHeaders can't be set by a request's custom headers: hop-by-hop headers,
+// and headers the HTTP client manages itself. Host is allowed by SCRAPE_ALLOW_HOST_HEADER.
+var BlockedRequestHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization", "Proxy-Connection",
+	"Te", "Trailer", "Transfer-Encoding", "Upgrade", "Content-Length", "Accept-Encoding",
+	"Expect", "Host",
+}
+
+// HTTPS enforcement modes for http:// targets, see ExtractionOptions.HTTPSMode
+const (
+	HTTPSModeAllow   = "allow"   // fetch as given
+	HTTPSModeUpgrade = "upgrade" // try https first, falling back to http
+	HTTPSModeRequire = "require" // reject with ErrHTTPSRequired
+)
+
+// Browser device emulation presets
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+)
+
+// Index page detection: this many teasers of at most this many words each
+const (
+	IndexMinTeasers     = 4
+	IndexMaxTeaserWords = 150
+)
+
+// Index page handling modes
+const (
+	IndexModeOff     = "off"
+	IndexModeLargest = "largest"
+	IndexModeLinks   = "links"
+)
+
+// Soft-404 detection: an error page this short is rejected on body text alone
+const Soft404MaxWords = 80
+
+// Client-side redirect detection
+const (
+	MaxClientRedirects     = 1    // meta refresh / JS redirects followed per fetch
+	MaxMetaRefreshDelay    = 5    // seconds; longer delays are page auto-reloads, not redirects
+	MaxJSRedirectPageBytes = 8192 // JS redirects are only trusted on small landing pages
+)
+
+// Upstream response headers reported in metadata when IncludeResponseInfo is set
+var ResponseInfoHeaders = []string{
+	"Content-Type",
+	"Content-Language",
+	"Server",
+	"CF-Ray",
+	"Cache-Control",
+	"Age",
+	"X-Cache",
+}
+
+// Paywall markers used by DetectPaywall
+const PaywallSelectors = `[class*="paywall"], [id*="paywall"], [class*="premium-content"], [class*="regwall"], [class*="subscriber-only"], [class*="piano-offer"], [id*="piano-offer"]`
+
+var PaywallPhrases = []string{
+	"subscribe to continue",
+	"subscribe to keep reading",
+	"subscribe to read",
+	"subscribers only",
+	"this article is for subscribers",
+	"this content is for subscribers",
+	"to continue reading, subscribe",
+	"create a free account to continue",
+	"sign in to continue reading",
+	"log in to continue reading",
+	"you have reached your free article limit",
+	"you've reached your free article limit",
+}
+
+// Login wall detection, see DetectLoginWall: login page path segments, and the word
+// count under which a page with a password field is taken for a login form
+var LoginPathSegments = []string{"login", "log-in", "signin", "sign-in", "sign_in", "auth", "oauth", "sso"}
+
+const LoginWallMaxWords = 150
+
+// Feed MIME types recognized on <link rel="alternate"> during discovery
+var FeedMimeTypes = []string{
+	"application/rss+xml",
+	"application/atom+xml",
+	"application/feed+json",
+}
+
+// Sitemap expansion in discovery, see DiscoverOptions.ExpandSitemaps
+const (
+	MaxExpandedSitemaps = 5    // declared sitemaps fetched
+	MaxSitemapPages     = 5000 // page URLs returned
+)
+
+// PDFContentType is the media type handled by the optional PDF extraction path
+const PDFContentType = "application/pdf"
+
+// MaxBodyBytesLimit caps per-request body size limit overrides
+const MaxBodyBytesLimit = 20_000_000
+
+// ProbeBodyBytes is how much of the body a validate probe reads to spot bot walls
+const ProbeBodyBytes = 65536
+
+// MaxAdSizeTolerance caps AdSizeTolerance, past which real photos start matching
+const MaxAdSizeTolerance = 10
+
+// Image reachability checks, see ValidateImages
+const (
+	ImageValidationCandidates  = 6 // images extracted, so unreachable ones can be replaced
+	ImageValidationConcurrency = 4
+	ImageValidationTimeout     = 5 * time.Second // for all the checks together
+)
+
+// Main image size probing; JPEG headers can sit behind tens of KB of EXIF data
+const (
+	ImageProbeBytes   = 65536
+	ImageProbeTimeout = 3 * time.Second
+)
+
+// Video providers
+const (
+	VideoProviderYouTube   = "youtube"
+	VideoProviderVimeo     = "vimeo"
+	VideoProviderNative    = "native"
+	VideoProviderOpenGraph = "opengraph"
+)
+
+// Rate limiting
+const (
+	MaxRateLimitedHosts = 1000 // buckets kept before idle ones are pruned
+)
+
+// HTTP dialing with the DNS cache
+const (
+	DialTimeout      = 30 * time.Second
+	DialKeepAlive    = 30 * time.Second
+	MaxDNSCacheHosts = 1000 // entries kept before expired ones are pruned
+)
+
+// Multi-page article defaults
+const (
+	DefaultMaxPages = 5
+	MaxPagesLimit   = 20 // Upper bound for per-request MaxPages
+)
+
+// Reading time defaults
+const (
+	DefaultWordsPerMinute = 200
+	DefaultCharsPerMinute = 500 // CJK reading speed
+)
+
+// Image ordering modes
+const (
+	ImageOrderScore    = "score"
+	ImageOrderDocument = "document"
+)
+
+// Image filter rejection reasons tallied in diagnostics
+const (
+	RejectImageSize    = "size"
+	RejectImageAspect  = "aspect"
+	RejectImageAdSize  = "adSize"
+	RejectImageBadHint = "badHint"
+	RejectImageHost    = "deniedHost"
 )
 
 // Image processing constants
@@ -79,3 +355,84 @@ var CloudflarePatterns = []string{
 	"why have i been blocked?",
 	"performance & security by cloudflare",
 }
+
+// Hero image thumbnails, see ThumbnailURL
+const (
+	ThumbnailWidth           = 200
+	WordPressThumbnailSuffix = "-150x150" // WordPress's default "thumbnail" size
+)
+
+// CDNs resizing images to a w query param
+var ThumbnailWidthParamHosts = []string{
+	"imgix.net",
+	"images.ctfassets.net",
+	"cdn.sanity.io",
+	"images.unsplash.com",
+	"wp.com",
+	"files.wordpress.com",
+	"images.prismic.io",
+}
+
+// Resize and format query parameters ignored when deduplicating image variants
+var ImageResizeParams = []string{
+	"w", "h", "width", "height", "resize", "fit", "crop", "quality", "q",
+	"auto", "dpr", "format", "fm", "s", "size", "ssl",
+}
+
+// Subdomains serving a site's pages under another host, see ResolveBaseURL
+var AlternateHostPrefixes = []string{"www.", "m.", "mobile.", "amp."}
+
+// Tracking query parameter prefixes stripped during URL normalization
+var TrackingParamPrefixes = []string{
+	"utm_",
+	"pk_",
+	"mtm_",
+	"hsa_",
+}
+
+// Click-ID, campaign and affiliate tracking query parameters stripped during URL
+// normalization. Generic names such as ref or v are left alone, since sites also use
+// them for content.
+var TrackingParams = []string{
+	"fbclid",
+	"gclid",
+	"gbraid",
+	"wbraid",
+	"dclid",
+	"msclkid",
+	"yclid",
+	"twclid",
+	"ttclid",
+	"igshid",
+	"mc_cid",
+	"mc_eid",
+	"_hsenc",
+	"_hsmi",
+	"_ga",
+	"_gl",
+	"ref_src",
+	"aff",
+	"aff_id",
+	"affid",
+	"affiliate",
+	"clickid",
+}
+
+// Cache-buster query parameters, stripped from image URLs only: on a CDN image they just
+// defeat caching, while a page may use the same names to pick its content
+var CacheBusterParams = []string{
+	"cb",
+	"cachebuster",
+	"cache_buster",
+	"_",
+	"ts",
+	"timestamp",
+	"ver",
+	"v",
+}
+
+// Fetch paths reported in logs
+const (
+	PathHTTP    = "http"
+	PathBrowser = "browser"
+)
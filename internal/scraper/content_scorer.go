@@ -2,6 +2,11 @@ package scraper
 
 import (
 	"strings"
+	"unicode/utf8"
+
+	"extract-html-scraper/internal/config"
+
+	"github.com/PuerkitoBio/goquery"
 )
 
 // ContentQuality represents quality metrics for extracted content
@@ -11,12 +16,52 @@ type ContentQuality struct {
 	ParagraphCount     int     `json:"paragraphCount"`     // Number of paragraphs
 	AvgParagraphLength int     `json:"avgParagraphLength"` // Average characters per paragraph
 	HasHeaders         bool    `json:"hasHeaders"`         // Contains headings
-	LinkDensity        float64 `json:"linkDensity"`        // Links per 1000 chars (lower is better)
+	LinkDensity        float64 `json:"linkDensity"`        // Share of text inside links, 0-1 (lower is better)
 	WordCount          int     `json:"wordCount"`          // Estimated word count
 }
 
-// ScoreContentQuality analyzes content and returns quality metrics
-func ScoreContentQuality(content, originalHTML string) ContentQuality {
+// ContentSignals carries structural measurements taken from the source HTML before flattening
+type ContentSignals struct {
+	LinkDensity float64 // Anchor text length / total text length
+	HasHeadings bool    // Source contains non-empty h1-h6 elements
+}
+
+// CalculateLinkDensity returns the share of text inside <a> tags for the selection
+func CalculateLinkDensity(selection *goquery.Selection) float64 {
+	totalChars := utf8.RuneCountInString(strings.Join(strings.Fields(selection.Text()), " "))
+	if totalChars == 0 {
+		return 0
+	}
+
+	linkChars := 0
+	selection.Find("a").Each(func(i int, s *goquery.Selection) {
+		linkChars += utf8.RuneCountInString(strings.Join(strings.Fields(s.Text()), " "))
+	})
+
+	density := float64(linkChars) / float64(totalChars)
+	if density > 1 {
+		density = 1
+	}
+	return density
+}
+
+// HasHeadings checks whether the selection contains any non-empty heading element
+func HasHeadings(selection *goquery.Selection) bool {
+	found := false
+	selection.Find(HeadingTags).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		found = strings.TrimSpace(s.Text()) != ""
+		return !found
+	})
+	return found
+}
+
+// ScoreContentQuality analyzes content and returns quality metrics using the default thresholds
+func ScoreContentQuality(content, originalHTML string, signals ContentSignals) ContentQuality {
+	return ScoreContentQualityWithConfig(content, originalHTML, signals, config.DefaultQualityConfig())
+}
+
+// ScoreContentQualityWithConfig analyzes content and returns quality metrics using custom thresholds
+func ScoreContentQualityWithConfig(content, originalHTML string, signals ContentSignals, cfg config.QualityConfig) ContentQuality {
 	if content == "" {
 		return ContentQuality{Score: 0}
 	}
@@ -24,10 +69,8 @@ func ScoreContentQuality(content, originalHTML string) ContentQuality {
 	// Basic metrics
 	wordCount, paragraphCount, avgParagraphLength := CalculateContentMetrics(content)
 
-	// Check for headers
-	hasHeaders := strings.Contains(content, "\n") &&
-		(strings.Contains(strings.ToLower(content), "heading") ||
-			strings.Count(content, "\n") > paragraphCount/2) // Rough heuristic
+	// Headings are detected in the source HTML since text output flattens them
+	hasHeaders := signals.HasHeadings
 
 	// Calculate text-to-HTML ratio
 	textToHTMLRatio := 0.0
@@ -35,17 +78,12 @@ func ScoreContentQuality(content, originalHTML string) ContentQuality {
 		textToHTMLRatio = float64(len(content)) / float64(len(originalHTML))
 	}
 
-	// Calculate link density (rough estimation)
-	linkCount := strings.Count(content, "http") + strings.Count(content, "www.")
-	charCount := len(content)
-	linkDensity := 0.0
-	if charCount > 0 {
-		linkDensity = float64(linkCount) / float64(charCount) * 1000
-	}
+	// Link density measured from anchors in the source HTML
+	linkDensity := signals.LinkDensity
 
 	// Calculate overall score (0-100)
 	score := calculateOverallScore(wordCount, paragraphCount, avgParagraphLength,
-		hasHeaders, textToHTMLRatio, linkDensity)
+		hasHeaders, textToHTMLRatio, linkDensity, cfg)
 
 	return ContentQuality{
 		Score:              score,
@@ -60,65 +98,29 @@ func ScoreContentQuality(content, originalHTML string) ContentQuality {
 
 // calculateOverallScore computes a 0-100 quality score
 func calculateOverallScore(wordCount, paragraphCount, avgParagraphLength int,
-	hasHeaders bool, textToHTMLRatio, linkDensity float64) int {
+	hasHeaders bool, textToHTMLRatio, linkDensity float64, cfg config.QualityConfig) int {
 
 	score := 0
 
-	// Word count scoring (0-25 points)
-	if wordCount >= 500 {
-		score += 25
-	} else if wordCount >= 200 {
-		score += 20
-	} else if wordCount >= 100 {
-		score += 15
-	} else if wordCount >= 50 {
-		score += 10
-	}
+	// Word count scoring (0-25 points by default)
+	score += scoreAtLeast(float64(wordCount), cfg.WordCountBands)
 
-	// Paragraph count scoring (0-20 points)
-	if paragraphCount >= 5 {
-		score += 20
-	} else if paragraphCount >= 3 {
-		score += 15
-	} else if paragraphCount >= 2 {
-		score += 10
-	} else if paragraphCount >= 1 {
-		score += 5
-	}
+	// Paragraph count scoring (0-20 points by default)
+	score += scoreAtLeast(float64(paragraphCount), cfg.ParagraphCountBands)
 
-	// Average paragraph length scoring (0-20 points)
-	if avgParagraphLength >= 200 {
-		score += 20
-	} else if avgParagraphLength >= 100 {
-		score += 15
-	} else if avgParagraphLength >= 50 {
-		score += 10
-	} else if avgParagraphLength >= 20 {
-		score += 5
-	}
+	// Average paragraph length scoring (0-20 points by default)
+	score += scoreAtLeast(float64(avgParagraphLength), cfg.AvgParagraphLengthBands)
 
-	// Structure scoring (0-15 points)
+	// Structure scoring (0-15 points by default)
 	if hasHeaders {
-		score += 15
+		score += cfg.HeadingsPoints
 	}
 
-	// Text-to-HTML ratio scoring (0-10 points)
-	if textToHTMLRatio >= 0.3 {
-		score += 10
-	} else if textToHTMLRatio >= 0.2 {
-		score += 7
-	} else if textToHTMLRatio >= 0.1 {
-		score += 5
-	}
+	// Text-to-HTML ratio scoring (0-10 points by default)
+	score += scoreAtLeast(textToHTMLRatio, cfg.TextToHTMLRatioBands)
 
-	// Link density penalty (0-10 points deducted)
-	if linkDensity <= 5 {
-		score += 10
-	} else if linkDensity <= 10 {
-		score += 5
-	} else if linkDensity > 20 {
-		score -= 10
-	}
+	// Link density bonus/penalty (+10 to -10 points by default)
+	score += scoreAtMost(linkDensity, cfg.LinkDensityBands)
 
 	// Ensure score is within bounds
 	if score > 100 {
@@ -130,3 +132,23 @@ func calculateOverallScore(wordCount, paragraphCount, avgParagraphLength int,
 
 	return score
 }
+
+// scoreAtLeast returns the points of the first band whose minimum the value reaches
+func scoreAtLeast(value float64, bands []config.ScoreBand) int {
+	for _, band := range bands {
+		if value >= band.Min {
+			return band.Points
+		}
+	}
+	return 0
+}
+
+// scoreAtMost returns the points of the first band whose maximum the value stays within
+func scoreAtMost(value float64, bands []config.CeilingBand) int {
+	for _, band := range bands {
+		if value <= band.Max {
+			return band.Points
+		}
+	}
+	return 0
+}
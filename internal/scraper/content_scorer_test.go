@@ -0,0 +1,127 @@
+package scraper
+
+import (
+	"math"
+	"testing"
+
+	"extract-html-scraper/internal/config"
+)
+
+const navLikeBlock = `<div>
+<p><a href="/a">World news today</a> <a href="/b">Business and markets</a> <a href="/c">Sports results</a></p>
+<p><a href="/d">Opinion and analysis</a> <a href="/e">Culture and arts</a> <a href="/f">Travel guides</a></p>
+</div>`
+
+const articleBlock = `<div>
+<p>The council approved the new transit plan on Tuesday after months of <a href="/hearings">public hearings</a> and debate.</p>
+<p>Officials said the first bus lanes will open next spring, with more routes following in the autumn of next year.</p>
+</div>`
+
+func TestCalculateLinkDensity(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		min, max float64
+	}{
+		{"no links", `<div><p>Plain text only here.</p></div>`, 0, 0},
+		{"all links", `<div><a href="/x">Everything is a link</a></div>`, 1, 1},
+		{"nav block", navLikeBlock, 0.8, 1},
+		{"article body", articleBlock, 0.01, 0.15},
+		{"empty", `<div></div>`, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateLinkDensity(parseDoc(t, tt.html).Find("div").First())
+			if got < tt.min || got > tt.max {
+				t.Errorf("CalculateLinkDensity() = %.3f, want in [%.2f, %.2f]", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestLinkHeavyBlockScoresLower(t *testing.T) {
+	score := func(html string) ContentQuality {
+		selection := parseDoc(t, html).Find("div").First()
+		signals := ContentSignals{LinkDensity: CalculateLinkDensity(selection), HasHeadings: HasHeadings(selection)}
+		return ScoreContentQuality(CleanWhitespace(ExtractTextFromElements(selection, TextElements, false)), html, signals)
+	}
+
+	nav := score(navLikeBlock)
+	article := score(articleBlock)
+	if nav.Score >= article.Score {
+		t.Errorf("nav block scored %d, article %d; want the nav block lower", nav.Score, article.Score)
+	}
+	if math.Abs(nav.LinkDensity-1) > 0.2 {
+		t.Errorf("nav link density = %.2f, want close to 1", nav.LinkDensity)
+	}
+}
+
+func TestHasHeadings(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{"h2", `<div><h2>Background</h2><p>Text</p></div>`, true},
+		{"h6", `<div><h6>Note</h6></div>`, true},
+		{"empty heading", `<div><h2> </h2><p>Text</p></div>`, false},
+		{"word heading in text", `<div><p>This heading is only a word.</p></div>`, false},
+		{"many newlines", "<div><p>One</p>\n\n\n\n<p>Two</p>\n\n\n\n<p>Three</p></div>", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasHeadings(parseDoc(t, tt.html).Find("div").First()); got != tt.want {
+				t.Errorf("HasHeadings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreContentQualityHeadings(t *testing.T) {
+	content := "The council approved the new transit plan.\n\nOfficials said bus lanes open next spring."
+	without := ScoreContentQuality(content, content, ContentSignals{})
+	with := ScoreContentQuality(content, content, ContentSignals{HasHeadings: true})
+
+	if !with.HasHeaders || without.HasHeaders {
+		t.Errorf("HasHeaders = %v with headings, %v without", with.HasHeaders, without.HasHeaders)
+	}
+	if with.Score <= without.Score {
+		t.Errorf("score with headings %d, without %d; want headings to add points", with.Score, without.Score)
+	}
+}
+
+func TestScoreContentQualityWithConfig(t *testing.T) {
+	content := "The council approved the new transit plan on Tuesday.\n\nOfficials said bus lanes open next spring."
+	signals := ContentSignals{LinkDensity: 0.05}
+
+	strict := config.DefaultQualityConfig()
+	strict.WordCountBands = []config.ScoreBand{{Min: 1000, Points: 25}}
+	strict.LinkDensityBands = []config.CeilingBand{{Max: 0.01, Points: 10}}
+
+	lenient := config.DefaultQualityConfig()
+	lenient.WordCountBands = []config.ScoreBand{{Min: 1, Points: 25}}
+
+	tests := []struct {
+		name string
+		cfg  config.QualityConfig
+		want int
+	}{
+		{"default", config.DefaultQualityConfig(), 35},
+		{"strict", strict, 25},
+		{"lenient", lenient, 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScoreContentQualityWithConfig(content, content, signals, tt.cfg).Score; got != tt.want {
+				t.Errorf("score = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := ScoreContentQuality(content, content, signals).Score, tests[0].want; got != want {
+		t.Errorf("ScoreContentQuality() = %d, want the default config's %d", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package scraper
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FindFeedLinks returns the absolute URLs of RSS, Atom and JSON feeds declared
+// with <link rel="alternate"> in the document
+func FindFeedLinks(doc *goquery.Document, baseURL string) []string {
+	baseURL = ResolveBaseURL(doc, baseURL)
+	seen := make(map[string]bool)
+	var feeds []string
+
+	doc.Find(`link[rel~="alternate"][href]`).Each(func(i int, s *goquery.Selection) {
+		feedType := strings.ToLower(strings.TrimSpace(s.AttrOr("type", "")))
+		if !ContainsAny(feedType, FeedMimeTypes) {
+			return
+		}
+
+		href := strings.TrimSpace(s.AttrOr("href", ""))
+		if href == "" {
+			return
+		}
+
+		feedURL, err := ResolveURL(href, baseURL)
+		if err != nil || seen[feedURL] {
+			return
+		}
+		seen[feedURL] = true
+		feeds = append(feeds, feedURL)
+	})
+
+	return feeds
+}
+
+// ParseRobotsSitemaps returns the absolute URLs of the Sitemap: directives in a robots.txt
+func ParseRobotsSitemaps(robots, robotsURL string) []string {
+	seen := make(map[string]bool)
+	var sitemaps []string
+
+	scanner := bufio.NewScanner(strings.NewReader(robots))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		name, value, found := strings.Cut(line, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(name), "sitemap") {
+			continue
+		}
+
+		// Drop trailing comments
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			value = value[:idx]
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		sitemapURL, err := ResolveURL(value, robotsURL)
+		if err != nil || seen[sitemapURL] {
+			continue
+		}
+		seen[sitemapURL] = true
+		sitemaps = append(sitemaps, sitemapURL)
+	}
+
+	return sitemaps
+}
+
+// sitemapDocument covers both sitemap formats: a <urlset> of page <url>s and a
+// <sitemapindex> of child <sitemap>s
+type sitemapDocument struct {
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// ParseSitemap returns the page URLs of a sitemap, or the child sitemap URLs of a
+// sitemap index. Gzipped sitemaps (sitemap.xml.gz served without Content-Encoding) are
+// decompressed first, up to maxBytes.
+func ParseSitemap(data []byte, maxBytes int) (pages, sitemaps []string, err error) {
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, fmt.Errorf("gzip: %w", err)
+		}
+		defer reader.Close()
+		if data, err = io.ReadAll(io.LimitReader(reader, int64(maxBytes))); err != nil {
+			return nil, nil, fmt.Errorf("gzip: %w", err)
+		}
+	}
+
+	var doc sitemapDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("sitemap XML: %w", err)
+	}
+
+	for _, entry := range doc.URLs {
+		if loc := strings.TrimSpace(entry.Loc); loc != "" {
+			pages = append(pages, loc)
+		}
+	}
+	for _, entry := range doc.Sitemaps {
+		if loc := strings.TrimSpace(entry.Loc); loc != "" {
+			sitemaps = append(sitemaps, loc)
+		}
+	}
+	return pages, sitemaps, nil
+}
@@ -0,0 +1,221 @@
+package scraper
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindFeedLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		head string
+		want []string
+	}{
+		{
+			name: "rss and atom",
+			head: `<link rel="alternate" type="application/rss+xml" href="/feed.xml"><link rel="alternate" type="application/atom+xml" href="https://example.com/atom.xml">`,
+			want: []string{"https://example.com/feed.xml", "https://example.com/atom.xml"},
+		},
+		{
+			name: "language alternates skipped",
+			head: `<link rel="alternate" hreflang="fr" href="/fr/"><link rel="alternate" type="application/feed+json" href="/feed.json">`,
+			want: []string{"https://example.com/feed.json"},
+		},
+		{
+			name: "duplicates collapsed",
+			head: `<link rel="alternate" type="application/rss+xml" href="/feed.xml"><link rel="alternate" type="application/rss+xml" href="https://example.com/feed.xml">`,
+			want: []string{"https://example.com/feed.xml"},
+		},
+		{
+			name: "none",
+			head: `<link rel="stylesheet" href="/site.css">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<html><head>"+tt.head+"</head><body></body></html>")
+			if got := FindFeedLinks(doc, "https://example.com/"); !equalStrings(got, tt.want) {
+				t.Errorf("FindFeedLinks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRobotsSitemaps(t *testing.T) {
+	tests := []struct {
+		name   string
+		robots string
+		want   []string
+	}{
+		{
+			name:   "absolute and relative",
+			robots: "User-agent: *\nDisallow: /admin\nSitemap: https://example.com/sitemap.xml\nsitemap: /news-sitemap.xml\n",
+			want:   []string{"https://example.com/sitemap.xml", "https://example.com/news-sitemap.xml"},
+		},
+		{
+			name:   "trailing comment and duplicate",
+			robots: "Sitemap: https://example.com/sitemap.xml # main\nSitemap: https://example.com/sitemap.xml\n",
+			want:   []string{"https://example.com/sitemap.xml"},
+		},
+		{
+			name:   "no directives",
+			robots: "User-agent: *\nDisallow:\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseRobotsSitemaps(tt.robots, "https://example.com/robots.txt"); !equalStrings(got, tt.want) {
+				t.Errorf("ParseRobotsSitemaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><link rel="alternate" type="application/rss+xml" href="/feed.xml"></head><body><p>Home</p></body></html>`)
+	})
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "User-agent: *\nSitemap: /sitemap.xml\n")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	result, err := newTestScraper().Discover(context.Background(), server.URL+"/some/article")
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+
+	if result.URL != server.URL+"/" {
+		t.Errorf("url = %q, want the homepage", result.URL)
+	}
+	if want := []string{server.URL + "/feed.xml"}; !equalStrings(result.Feeds, want) {
+		t.Errorf("feeds = %v, want %v", result.Feeds, want)
+	}
+	if want := []string{server.URL + "/sitemap.xml"}; !equalStrings(result.Sitemaps, want) {
+		t.Errorf("sitemaps = %v, want %v", result.Sitemaps, want)
+	}
+}
+
+// gzipped compresses data like a sitemap.xml.gz file
+func gzipped(t testing.TB, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(data)); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+const (
+	urlsetXML = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc>https://example.com/news/one</loc></url>
+<url><loc> https://example.com/news/two </loc><lastmod>2024-03-05</lastmod></url>
+</urlset>`
+	sitemapIndexXML = `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<sitemap><loc>https://example.com/sitemap-news.xml.gz</loc></sitemap>
+<sitemap><loc>https://example.com/sitemap-pages.xml</loc></sitemap>
+</sitemapindex>`
+)
+
+func TestParseSitemap(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		wantPages    []string
+		wantSitemaps []string
+		wantErr      bool
+	}{
+		{"urlset", []byte(urlsetXML), []string{"https://example.com/news/one", "https://example.com/news/two"}, nil, false},
+		{"gzipped urlset", gzipped(t, urlsetXML), []string{"https://example.com/news/one", "https://example.com/news/two"}, nil, false},
+		{"sitemap index", []byte(sitemapIndexXML), nil, []string{"https://example.com/sitemap-news.xml.gz", "https://example.com/sitemap-pages.xml"}, false},
+		{"not xml", []byte("<html><body>Not found</body>"), nil, nil, true},
+		{"corrupt gzip", []byte{0x1f, 0x8b, 0x00}, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages, sitemaps, err := ParseSitemap(tt.data, 1<<20)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSitemap error = %v, want error %v", err, tt.wantErr)
+			}
+			if !equalStrings(pages, tt.wantPages) || !equalStrings(sitemaps, tt.wantSitemaps) {
+				t.Errorf("ParseSitemap = %q, %q, want %q, %q", pages, sitemaps, tt.wantPages, tt.wantSitemaps)
+			}
+		})
+	}
+}
+
+func TestDiscoverExpandSitemaps(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><p>Home</p></body></html>`)
+	})
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Sitemap: /sitemap_index.xml\nSitemap: /sitemap.xml.gz\nSitemap: /encoded.xml\nSitemap: /missing.xml\n")
+	})
+	mux.HandleFunc("/sitemap_index.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, sitemapIndexXML)
+	})
+	mux.HandleFunc("/sitemap.xml.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Write(gzipped(t, urlsetXML))
+	})
+	mux.HandleFunc("/encoded.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(gzipped(t, `<urlset><url><loc>https://example.com/news/three</loc></url><url><loc>https://example.com/news/one</loc></url></urlset>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		expand       bool
+		wantPages    []string
+		wantChildren []string
+	}{
+		{"sitemaps only listed", false, nil, nil},
+		{
+			name:         "sitemaps expanded",
+			expand:       true,
+			wantPages:    []string{"https://example.com/news/one", "https://example.com/news/two", "https://example.com/news/three"},
+			wantChildren: []string{"https://example.com/sitemap-news.xml.gz", "https://example.com/sitemap-pages.xml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestScraper().DiscoverWithOptions(context.Background(), server.URL, DiscoverOptions{ExpandSitemaps: tt.expand})
+			if err != nil {
+				t.Fatalf("discover: %v", err)
+			}
+			if len(result.Sitemaps) != 4 {
+				t.Errorf("sitemaps = %q, want the 4 declared", result.Sitemaps)
+			}
+			if !equalStrings(result.Pages, tt.wantPages) {
+				t.Errorf("pages = %q, want %q", result.Pages, tt.wantPages)
+			}
+			if !equalStrings(result.ChildSitemaps, tt.wantChildren) {
+				t.Errorf("child sitemaps = %q, want %q", result.ChildSitemaps, tt.wantChildren)
+			}
+		})
+	}
+}
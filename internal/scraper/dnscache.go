@@ -0,0 +1,93 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// DNSCache resolves hostnames once per TTL for the HTTP transport's dialer, safe for
+// concurrent use. Repeated scrapes of the same hosts skip the lookup.
+type DNSCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	resolver *net.Resolver
+	dialer   *net.Dialer
+	entries  map[string]dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// NewDNSCache creates a cache keeping lookups for ttl
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	return &DNSCache{
+		ttl:      ttl,
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{Timeout: DialTimeout, KeepAlive: DialKeepAlive},
+		entries:  make(map[string]dnsEntry),
+	}
+}
+
+// DialContext dials addr through the cached addresses of its host, trying each in turn
+func (c *DNSCache) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return c.dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := c.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialErr error
+	for _, ip := range addrs {
+		conn, err := c.dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = errors.Join(dialErr, err)
+	}
+	return nil, dialErr
+}
+
+// lookup returns the cached addresses for host, resolving them when missing or expired
+func (c *DNSCache) lookup(ctx context.Context, host string) ([]string, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, exists := c.entries[host]
+	c.mu.Unlock()
+	if exists && now.Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pruneExpired(now)
+	c.entries[host] = dnsEntry{addrs: addrs, expires: now.Add(c.ttl)}
+	return addrs, nil
+}
+
+// pruneExpired drops expired entries so the map doesn't grow unbounded
+func (c *DNSCache) pruneExpired(now time.Time) {
+	if len(c.entries) < MaxDNSCacheHosts {
+		return
+	}
+
+	for host, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, host)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package scraper
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDNSCacheDial(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port := serverURL.Port()
+
+	tests := []struct {
+		name    string
+		addr    string
+		entry   *dnsEntry
+		wantErr bool
+	}{
+		{"cached host skips the lookup", "cached.invalid:" + port, &dnsEntry{addrs: []string{"127.0.0.1"}, expires: time.Now().Add(time.Minute)}, false},
+		{"falls through unreachable addresses", "cached.invalid:" + port, &dnsEntry{addrs: []string{"127.0.0.2", "127.0.0.1"}, expires: time.Now().Add(time.Minute)}, false},
+		{"expired entry is resolved again", "cached.invalid:" + port, &dnsEntry{addrs: []string{"127.0.0.1"}, expires: time.Now().Add(-time.Minute)}, true},
+		{"ip literal dialed directly", "127.0.0.1:" + port, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewDNSCache(time.Minute)
+			if tt.entry != nil {
+				cache.entries["cached.invalid"] = *tt.entry
+			}
+
+			conn, err := cache.DialContext(context.Background(), "tcp", tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("dial error = %v, want error %v", err, tt.wantErr)
+			}
+			if conn != nil {
+				conn.Close()
+			}
+		})
+	}
+}
+
+func TestHTTPClientReusesConnections(t *testing.T) {
+	var opened atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(articleHTML("Pooled")))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			opened.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewHTTPClient()
+	for i := 0; i < 20; i++ {
+		if _, err := client.Fetch(context.Background(), server.URL, FetchOptions{}, 0); err != nil {
+			t.Fatalf("fetch %d: %v", i, err)
+		}
+	}
+	if got := opened.Load(); got != 1 {
+		t.Errorf("opened %d connections for 20 sequential fetches, want 1", got)
+	}
+}
+
+// BenchmarkFetchSameHost fetches one host concurrently, reporting the connections opened
+// so a pooling regression shows next to the timing
+func BenchmarkFetchSameHost(b *testing.B) {
+	var opened atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(articleHTML("Pooled")))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			opened.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewHTTPClient()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.Fetch(context.Background(), server.URL, FetchOptions{}, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.ReportMetric(float64(opened.Load()), "conns")
+}
@@ -1,5 +1,11 @@
 package scraper
 
+import (
+	"time"
+
+	"extract-html-scraper/internal/config"
+)
+
 // ExtractionOptions defines configurable options for article extraction
 type ExtractionOptions struct {
 	PreserveHTML      bool   `json:"preserveHtml"`
@@ -8,6 +14,243 @@ type ExtractionOptions struct {
 	MinParagraphChars int    `json:"minParagraphChars"`
 	RemoveComments    bool   `json:"removeComments"`
 	OutputFormat      string `json:"outputFormat"` // "text", "markdown", "html"
+
+	// SkipSanitization returns PreserveHTML content without the bluemonday pass, keeping
+	// ids, data-* attributes and whatever else the page had, scripts included. UNSAFE to
+	// render from untrusted sources; only for pipelines that sanitize the HTML themselves.
+	SkipSanitization bool `json:"skipSanitization"`
+
+	// PreserveLineBreaks keeps <br> line breaks inside paragraphs as single newlines in text
+	// content, for poetry, lyrics and addresses
+	PreserveLineBreaks bool `json:"preserveLineBreaks"`
+
+	// MarkHeadings prefixes headings in text content with "# " to "###### " by level, so
+	// section boundaries survive flattening without asking for Markdown
+	MarkHeadings bool `json:"markHeadings"`
+
+	// StripTrackingParams removes utm_*, click-ID and affiliate params from returned image
+	// URLs and the next, previous and article links, and cache-buster params from image URLs
+	StripTrackingParams bool `json:"stripTrackingParams"`
+
+	// IncludeVideos extracts embedded YouTube/Vimeo players, <video> elements and og:video
+	IncludeVideos bool `json:"includeVideos"`
+
+	// FullPage skips readability and the content-container heuristic and
+	// extracts text from the whole body, including sidebars
+	FullPage bool `json:"fullPage"`
+
+	// StrictSelector limits extraction to the elements it matches, with no readability
+	// or fallback; no match yields empty content and SelectorNotMatched
+	StrictSelector string `json:"strictSelector,omitempty"`
+
+	// IndexMode is IndexModeOff (index pages are extracted like articles, only flagged in
+	// IndexPage), IndexModeLargest (content from the largest teaser on index pages) or
+	// IndexModeLinks (the same, plus the teased article URLs in ArticleLinks for crawling)
+	IndexMode string `json:"indexMode"`
+
+	// PreferOGMainImage makes og:image the MainImage whenever it passes the image filters,
+	// instead of the best-scoring candidate
+	PreferOGMainImage bool `json:"preferOgMainImage"`
+
+	// MergeOGImage counts og:image and an in-page image at the same path as one image in
+	// Images whatever their query strings, e.g. a share-card crop of the hero
+	MergeOGImage bool `json:"mergeOgImage"`
+
+	// ImageOrder is "score" (best first) or "document" (in-article images in page order)
+	ImageOrder string `json:"imageOrder"`
+
+	// IncludeTitleCandidates lists every title found with its source in TitleCandidates,
+	// Title's first, for clients preferring e.g. the h1 to an SEO-stuffed og:title
+	IncludeTitleCandidates bool `json:"includeTitleCandidates"`
+
+	// IncludeRecipe returns a page's schema.org Recipe (JSON-LD, else microdata) in Recipe
+	IncludeRecipe bool `json:"includeRecipe"`
+
+	// IncludeBreadcrumbs returns the page's breadcrumb trail (JSON-LD BreadcrumbList, else
+	// a breadcrumb nav) in Breadcrumbs, giving its category hierarchy
+	IncludeBreadcrumbs bool `json:"includeBreadcrumbs"`
+
+	// IncludeStructuredData returns all parsed schema.org JSON-LD objects
+	IncludeStructuredData bool `json:"includeStructuredData"`
+
+	// IncludeMetaTags returns every <meta property|name> in the head in MetaTags, for
+	// metadata the response has no field for
+	IncludeMetaTags bool `json:"includeMetaTags"`
+
+	// FollowPagination fetches rel="next" pages on the same host and appends their content,
+	// up to MaxPages (capped at MaxPagesLimit)
+	FollowPagination bool `json:"followPagination"`
+	MaxPages         int  `json:"maxPages"`
+
+	// Reading speed used for ReadingTime; CJK languages are measured in characters
+	WordsPerMinute int `json:"wordsPerMinute"`
+	CharsPerMinute int `json:"charsPerMinute"`
+
+	// IncludeReadingLevel scores the content's Flesch Reading Ease and Flesch-Kincaid grade
+	// (approximate, English-oriented syllable counting)
+	IncludeReadingLevel bool `json:"includeReadingLevel"`
+
+	// MaxContentLength caps Content in characters, cutting at a sentence or word boundary
+	// (zero means no limit; HTML content is never cut). Only Content and what is derived
+	// from it (hashes, chunks) are cut; paragraphs, sections, Markdown and quality are not.
+	MaxContentLength int `json:"maxContentLength,omitempty"`
+
+	// ChunkSize also returns the content in Chunks of at most this many characters, split
+	// between paragraphs where possible, for LLM input limits (0 disables)
+	ChunkSize int `json:"chunkSize,omitempty"`
+
+	// Preview extracts only link-preview metadata (title, description, og:image, authors
+	// and dates), skipping readability, content extraction and quality scoring
+	Preview bool `json:"preview"`
+
+	// IncludeMarkdown also renders the content as Markdown in ContentMarkdown, from the same
+	// readability parse; Content keeps the OutputFormat rendering
+	IncludeMarkdown bool `json:"includeMarkdown"`
+
+	// KeepInlineImages keeps content images at their position in the Markdown and HTML
+	// renderings, with absolute URLs; Images is still returned
+	KeepInlineImages bool `json:"keepInlineImages"`
+
+	// IncludeDiagnostics reports the extraction method, content selector, image filtering
+	// and fetch path in Diagnostics
+	IncludeDiagnostics bool `json:"includeDiagnostics"`
+
+	// IncludeParagraphs returns the content as one entry per paragraph-level block
+	IncludeParagraphs bool `json:"includeParagraphs"`
+
+	// IncludeSections returns the content grouped under its h1-h6 headings
+	IncludeSections bool `json:"includeSections"`
+
+	// IncludeMainImageSize returns MainImage's pixel size, read from og:image:width/height,
+	// <img> attributes or the URL, else by fetching the start of the image once
+	IncludeMainImageSize bool `json:"includeMainImageSize"`
+
+	// ValidateImages checks the returned images with HEAD requests, dropping those that
+	// don't answer 2xx with an image content type. The extractor returns spare images
+	// (ImageValidationCandidates) for the scraper to cut back to the top ones that pass.
+	ValidateImages bool `json:"validateImages"`
+
+	// IncludeThumbnail returns ThumbnailURL, MainImage rewritten to a small size on CDNs
+	// with known resize URL patterns, or MainImage itself elsewhere
+	IncludeThumbnail bool `json:"includeThumbnail"`
+
+	// IncludeQuotes returns blockquotes and pullquotes in Quotes; they stay in the content too
+	IncludeQuotes bool `json:"includeQuotes"`
+
+	// IncludeTOC returns the page's table of contents (a .toc block or nav of jump links) in TOC
+	IncludeTOC bool `json:"includeToc"`
+
+	// IncludeImagePositions returns the content images in ImagePositions with the number of
+	// paragraphs before each, counted like Paragraphs, for placing them in reflowed text
+	IncludeImagePositions bool `json:"includeImagePositions"`
+
+	// IncludeComments returns the reader comments section in Comments, one entry per comment
+	IncludeComments bool `json:"includeComments"`
+
+	// UseAppData reads the article from Next.js/Nuxt inline state when the HTML is a shell,
+	// sparing the browser render
+	UseAppData bool `json:"useAppData"`
+
+	// UseTemplates reads the article from <template> and script-template markup when the
+	// page is otherwise a shell, as web-component sites clone it in client-side
+	UseTemplates bool `json:"useTemplates"`
+
+	// UseMicrodata falls back to itemprop microdata for title, content, author and publish date
+	UseMicrodata bool `json:"useMicrodata"`
+
+	// PaywallFallback retries a paywalled HTTP result in the browser, keeping
+	// whichever result is not paywalled or has more content
+	PaywallFallback bool `json:"paywallFallback"`
+
+	// ExtractPDF extracts text and title from application/pdf responses instead of rejecting them
+	ExtractPDF bool `json:"extractPdf"`
+
+	// IncludeResponseInfo reports the upstream status code and key response headers in metadata
+	IncludeResponseInfo bool `json:"includeResponseInfo"`
+
+	// IncludeRedirects reports the HTTP redirect chain, with per-hop timing, in metadata
+	IncludeRedirects bool `json:"includeRedirects"`
+
+	// Browser fallback rendering: window size (zero keeps the default) and
+	// device preset, DeviceDesktop or DeviceMobile
+	BrowserWindowWidth  int    `json:"browserWindowWidth,omitempty"`
+	BrowserWindowHeight int    `json:"browserWindowHeight,omitempty"`
+	BrowserDevice       string `json:"browserDevice,omitempty"`
+
+	// SettleDelayMs waits after the browser body is ready before capturing HTML,
+	// capped at MaxSettleDelay
+	SettleDelayMs int `json:"settleDelayMs,omitempty"`
+
+	// DismissConsent accepts cookie-consent modals in the browser before capturing the page
+	DismissConsent bool `json:"dismissConsent"`
+
+	// BlankRetries renders the page again, with a longer settle delay, when the browser
+	// content comes out blank; capped at MaxBlankRetries
+	BlankRetries int `json:"blankRetries"`
+
+	// AcceptLanguage replaces DefaultAcceptLanguage for the HTTP fetch and the browser,
+	// to scrape a site's other-language version
+	AcceptLanguage string `json:"acceptLanguage,omitempty"`
+
+	// Referer is sent by the HTTP fetch and the browser, DefaultReferer by default; empty
+	// sends none. Some sites serve other content, or unlock paywalls, by referer.
+	Referer string `json:"referer"`
+
+	// Headers are extra request headers for the HTTP fetch and the browser, overriding the
+	// defaults and sent only to the target's site. Hop-by-hop and client-managed headers
+	// are dropped (see BlockedRequestHeaders); Host only reaches the HTTP fetch, and only
+	// with SCRAPE_ALLOW_HOST_HEADER.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// MaxBodyBytes overrides the service's upstream body size limit for the HTTP fetch
+	// (zero keeps it), up to MaxBodyBytesLimit; a cut body sets Metadata.BodyTruncated
+	MaxBodyBytes int `json:"maxBodyBytes,omitempty"`
+
+	// DisableAlternates skips the AMP/mobile alternate URLs tried when the page is blocked,
+	// in both the HTTP fetch and the browser, so only the requested URL is scraped.
+	// SCRAPE_DISABLE_ALTERNATES turns them off for every request.
+	DisableAlternates bool `json:"disableAlternates"`
+
+	// HTTPSMode handles http:// targets: HTTPSModeAllow fetches them as given,
+	// HTTPSModeUpgrade tries https first and falls back to http, HTTPSModeRequire rejects
+	// them with ErrHTTPSRequired. Empty uses SCRAPE_HTTPS_MODE.
+	HTTPSMode string `json:"httpsMode,omitempty"`
+
+	// Cache validators from a previous scrape; a 304 upstream yields ErrNotModified
+	IfNoneMatch     string `json:"ifNoneMatch,omitempty"`
+	IfModifiedSince string `json:"ifModifiedSince,omitempty"`
+
+	// Image size filters; zero keeps the service defaults
+	MinImageShortSide int     `json:"minImageShortSide,omitempty"`
+	MinImageArea      int     `json:"minImageArea,omitempty"`
+	MinImageAspect    float64 `json:"minImageAspect,omitempty"`
+	MaxImageAspect    float64 `json:"maxImageAspect,omitempty"`
+
+	// Image filters, each on by default: minimum size, aspect ratio, standard ad
+	// dimensions, and ad/icon hints in the URL or markup
+	FilterImageSize   bool `json:"filterImageSize"`
+	FilterImageAspect bool `json:"filterImageAspect"`
+	FilterAdSizes     bool `json:"filterAdSizes"`
+	FilterBadHints    bool `json:"filterBadHints"`
+
+	// AdSizeTolerance also filters images within this many pixels of a standard ad size
+	// in each dimension, like a 729x91 bordered banner; zero keeps exact matching, and
+	// it is capped at MaxAdSizeTolerance
+	AdSizeTolerance int `json:"adSizeTolerance,omitempty"`
+
+	// ImageExtensions replaces the accepted image file extensions (empty keeps the defaults)
+	ImageExtensions []string `json:"imageExtensions,omitempty"`
+
+	// DeniedImageHosts rejects images from these hosts and their subdomains, on top of
+	// SCRAPE_IMAGE_DENYLIST and whatever the other image filters allow
+	DeniedImageHosts []string `json:"deniedImageHosts,omitempty"`
+
+	// AllowExtensionlessImages accepts image URLs without a file extension when the page
+	// declares an image MIME type for them (og:image:type or <picture><source type>)
+	AllowExtensionlessImages bool `json:"allowExtensionlessImages"`
+
+	// QualityConfig overrides the quality scoring thresholds (nil uses the service config)
+	QualityConfig *config.QualityConfig `json:"qualityConfig,omitempty"`
 }
 
 // DefaultExtractionOptions returns sensible defaults for extraction
@@ -19,7 +262,133 @@ func DefaultExtractionOptions() ExtractionOptions {
 		MinParagraphChars: 40,
 		RemoveComments:    true,
 		OutputFormat:      "text",
+		SkipSanitization:  false,
+
+		PreserveLineBreaks:    false,
+		MarkHeadings:          false,
+		StripTrackingParams:   false,
+		IncludeVideos:         false,
+		FullPage:              false,
+		IncludeStructuredData: false,
+		IncludeMetaTags:       false,
+		IncludeRecipe:         false,
+		IncludeBreadcrumbs:    false,
+		FollowPagination:      false,
+		MaxPages:              DefaultMaxPages,
+		IncludeResponseInfo:   false,
+		IncludeRedirects:      false,
+		IncludeParagraphs:     false,
+		IncludeSections:       false,
+		IncludeQuotes:         false,
+		IncludeTOC:            false,
+		IncludeMainImageSize:  false,
+		IncludeThumbnail:      false,
+		ValidateImages:        false,
+		IncludeComments:       false,
+		IncludeReadingLevel:   false,
+		Preview:               false,
+		IncludeMarkdown:       false,
+		KeepInlineImages:      false,
+		IndexMode:             IndexModeOff,
+		IncludeDiagnostics:    false,
+		PaywallFallback:       false,
+		ExtractPDF:            false,
+		BrowserDevice:         DeviceDesktop,
+		BlankRetries:          DefaultBlankRetries,
+		Referer:               DefaultReferer,
+		DismissConsent:        false,
+		DisableAlternates:     false,
+		UseMicrodata:          true,
+		UseAppData:            true,
+		UseTemplates:          true,
+		PreferOGMainImage:     true,
+		MergeOGImage:          false,
+
+		AllowExtensionlessImages: false,
+		IncludeTitleCandidates:   false,
+		IncludeImagePositions:    false,
+
+		FilterImageSize:   true,
+		FilterImageAspect: true,
+		FilterAdSizes:     true,
+		FilterBadHints:    true,
+
+		WordsPerMinute: DefaultWordsPerMinute,
+		CharsPerMinute: DefaultCharsPerMinute,
+	}
+}
+
+// fetchOptions derives the HTTP fetch inputs from the extraction options
+func (o ExtractionOptions) fetchOptions() FetchOptions {
+	return FetchOptions{
+		IfNoneMatch:     o.IfNoneMatch,
+		IfModifiedSince: o.IfModifiedSince,
+		AcceptPDF:       o.ExtractPDF,
+		AcceptLanguage:  o.AcceptLanguage,
+		TraceRedirects:  o.IncludeRedirects,
+		MaxBodyBytes:    o.MaxBodyBytes,
+		NoAlternates:    o.DisableAlternates,
+		Referer:         &o.Referer,
+		Headers:         o.Headers,
+	}
+}
+
+// browserOptions derives the browser fallback settings from the extraction options
+func (o ExtractionOptions) browserOptions() BrowserOptions {
+	opts := OptimizedBrowserOptions()
+	if o.BrowserWindowWidth > 0 {
+		opts.WindowWidth = o.BrowserWindowWidth
+	}
+	if o.BrowserWindowHeight > 0 {
+		opts.WindowHeight = o.BrowserWindowHeight
+	}
+	opts.Device = o.BrowserDevice
+	opts.AcceptLanguage = o.AcceptLanguage
+	opts.Referer = o.Referer
+	opts.Headers = o.Headers
+	opts.DismissConsent = o.DismissConsent
+	opts.NoAlternates = o.DisableAlternates
+	if o.SettleDelayMs > 0 {
+		opts.SettleDelay = time.Duration(o.SettleDelayMs) * time.Millisecond
+		if opts.SettleDelay > MaxSettleDelay {
+			opts.SettleDelay = MaxSettleDelay
+		}
+	}
+	return opts
+}
+
+// blankRetries bounds the number of re-renders for blank browser content
+func (o ExtractionOptions) blankRetries() int {
+	if o.BlankRetries > MaxBlankRetries {
+		return MaxBlankRetries
+	}
+	return o.BlankRetries
+}
+
+// imageConfig overlays the per-request image size filters on the given config
+func (o ExtractionOptions) imageConfig(cfg config.ImageConfig) config.ImageConfig {
+	if o.MinImageShortSide > 0 {
+		cfg.MinShortSide = o.MinImageShortSide
+	}
+	if o.MinImageArea > 0 {
+		cfg.MinArea = o.MinImageArea
+	}
+	if o.MinImageAspect > 0 {
+		cfg.MinAspect = o.MinImageAspect
+	}
+	if o.MaxImageAspect > 0 {
+		cfg.MaxAspect = o.MaxImageAspect
+	}
+	if o.AdSizeTolerance > 0 {
+		cfg.AdSizeTol = min(o.AdSizeTolerance, MaxAdSizeTolerance)
+	}
+	if len(o.ImageExtensions) > 0 {
+		cfg.Extensions = o.ImageExtensions
+	}
+	if len(o.DeniedImageHosts) > 0 {
+		cfg.DeniedHosts = append(append([]string(nil), cfg.DeniedHosts...), o.DeniedImageHosts...)
 	}
+	return cfg
 }
 
 // HTMLExtractionOptions returns options for HTML output
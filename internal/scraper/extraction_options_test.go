@@ -0,0 +1,169 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+func TestBrowserOptionsViewport(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		device     string
+		wantWidth  int
+		wantHeight int
+	}{
+		{"defaults", 0, 0, "", DefaultWindowWidth, DefaultWindowHeight},
+		{"custom viewport", 390, 844, DeviceDesktop, 390, 844},
+		{"mobile device", 0, 0, DeviceMobile, DefaultWindowWidth, DefaultWindowHeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.BrowserWindowWidth = tt.width
+			options.BrowserWindowHeight = tt.height
+			options.BrowserDevice = tt.device
+
+			opts := options.browserOptions()
+			if opts.WindowWidth != tt.wantWidth || opts.WindowHeight != tt.wantHeight || opts.Device != tt.device {
+				t.Errorf("browser options = %dx%d %q, want %dx%d %q", opts.WindowWidth, opts.WindowHeight, opts.Device, tt.wantWidth, tt.wantHeight, tt.device)
+			}
+		})
+	}
+}
+
+func TestBrowserOptionsSettleDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		delayMs int
+		want    time.Duration
+	}{
+		{"none", 0, 0},
+		{"requested delay", 1500, 1500 * time.Millisecond},
+		{"capped", 60000, MaxSettleDelay},
+		{"negative ignored", -200, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.SettleDelayMs = tt.delayMs
+			if got := options.browserOptions().SettleDelay; got != tt.want {
+				t.Errorf("settle delay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmulationActionLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantTasks      int
+		wantLocale     string
+	}{
+		{"viewport only", "", 1, ""},
+		{"language adds locale and headers", "fr-FR,fr;q=0.9", 3, "fr_FR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultBrowserOptions()
+			opts.AcceptLanguage = tt.acceptLanguage
+
+			tasks, ok := EmulationAction(opts).(chromedp.Tasks)
+			if !ok || len(tasks) != tt.wantTasks {
+				t.Errorf("emulation tasks = %d, want %d", len(tasks), tt.wantTasks)
+			}
+			if tt.wantLocale != "" && primaryLocale(tt.acceptLanguage) != tt.wantLocale {
+				t.Errorf("locale = %q, want %q", primaryLocale(tt.acceptLanguage), tt.wantLocale)
+			}
+		})
+	}
+}
+
+func TestBuildChromeOptionsLaunch(t *testing.T) {
+	base := len(BuildChromeOptions(DefaultBrowserOptions()))
+
+	tests := []struct {
+		name       string
+		execPath   string
+		extraFlags []string
+		want       int
+	}{
+		{"autodetected binary", "", nil, base},
+		{"exec path", "/opt/chrome/chrome", nil, base + 1},
+		{"extra flags", "", []string{"--disable-http2", "--lang=fr"}, base + 2},
+		{"empty flag skipped", "", []string{"--"}, base},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultBrowserOptions()
+			opts.ExecPath = tt.execPath
+			opts.ExtraFlags = tt.extraFlags
+			if got := len(BuildChromeOptions(opts)); got != tt.want {
+				t.Errorf("chrome options = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChromeFlag(t *testing.T) {
+	tests := []struct {
+		flag      string
+		wantName  string
+		wantValue interface{}
+	}{
+		{"--disable-http2", "disable-http2", true},
+		{"--lang=fr-FR", "lang", "fr-FR"},
+		{"-proxy-server=http://proxy:3128", "proxy-server", "http://proxy:3128"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			name, value := parseChromeFlag(tt.flag)
+			if name != tt.wantName || value != tt.wantValue {
+				t.Errorf("parseChromeFlag(%q) = %q, %v, want %q, %v", tt.flag, name, value, tt.wantName, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestEmulationActionReferer(t *testing.T) {
+	tests := []struct {
+		name           string
+		referer        string
+		acceptLanguage string
+		want           network.Headers
+	}{
+		{"default referer", DefaultReferer, "", network.Headers{"Referer": DefaultReferer}},
+		{"configured referer with language", "https://t.co/", "de-DE", network.Headers{"Referer": "https://t.co/", "Accept-Language": "de-DE"}},
+		{"no referer", "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.Referer = tt.referer
+			options.AcceptLanguage = tt.acceptLanguage
+
+			var got network.Headers
+			tasks, _ := EmulationAction(options.browserOptions()).(chromedp.Tasks)
+			for _, task := range tasks {
+				if params, ok := task.(*network.SetExtraHTTPHeadersParams); ok {
+					got = params.Headers
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extra headers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
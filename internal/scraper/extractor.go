@@ -1,8 +1,10 @@
 package scraper
 
 import (
+	"errors"
 	"strings"
 
+	"extract-html-scraper/internal/config"
 	"extract-html-scraper/internal/models"
 
 	"github.com/PuerkitoBio/goquery"
@@ -13,6 +15,7 @@ import (
 type ArticleExtractor struct {
 	sanitizer     *bluemonday.Policy
 	htmlSanitizer *bluemonday.Policy
+	qualityConfig config.QualityConfig
 }
 
 func NewArticleExtractor() *ArticleExtractor {
@@ -26,46 +29,235 @@ func NewArticleExtractor() *ArticleExtractor {
 	return &ArticleExtractor{
 		sanitizer:     policy,
 		htmlSanitizer: htmlPolicy,
+		qualityConfig: config.LoadQualityConfig(),
 	}
 }
 
-// ExtractArticleWithOptions extracts content with configurable options
-func (ae *ArticleExtractor) ExtractArticleWithOptions(html, baseURL string, options ExtractionOptions) models.ScrapeResponse {
+// ErrEmptyDocument is wrapped in a ContentExtractionError when the page parsed but has no
+// HTML body to extract from, such as a JSON error served as text/html
+var ErrEmptyDocument = errors.New("document has no HTML body")
+
+// ExtractArticleWithOptions extracts content with configurable options. Unparseable or
+// bodiless documents return a *models.ContentExtractionError.
+func (ae *ArticleExtractor) ExtractArticleWithOptions(html, baseURL string, options ExtractionOptions) (models.ScrapeResponse, error) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
-		return models.ScrapeResponse{
-			Images: []string{},
-		}
+		return models.ScrapeResponse{Images: []string{}}, &models.ContentExtractionError{Step: "parse", Err: err}
+	}
+	if !hasDocumentBody(doc) {
+		return models.ScrapeResponse{Images: []string{}}, &models.ContentExtractionError{Step: "parse", Err: ErrEmptyDocument}
+	}
+
+	// Link previews stop at the metadata
+	if options.Preview {
+		return ae.extractPreview(doc, baseURL, options), nil
 	}
 
 	title := ae.extractTitle(doc)
 	description := ae.extractDescription(doc)
 
+	// Locate the subtree the article content comes from. Requested comments are returned
+	// on their own, so their section is kept out of readability's reach.
+	contentDoc := doc
+	if options.IncludeComments {
+		contentDoc = goquery.CloneDocument(doc)
+		contentDoc.Find(CommentSectionSelectors).Remove()
+	}
+	source := ae.resolveContentSource(contentDoc, options)
+
+	// Measure structure before the HTML is flattened to text
+	signals := ContentSignals{
+		LinkDensity: CalculateLinkDensity(source.selection),
+		HasHeadings: HasHeadings(source.selection),
+	}
+
+	// Content images rendered in place, resolved like the Images array
+	imageExtractor := NewImageExtractorWithOptions(options)
+	inlineImage := inlineImageFunc(imageExtractor, doc, baseURL, options)
+
 	var content string
 	if options.PreserveHTML {
-		content = ae.extractContentAsHTML(doc)
+		content = ae.extractContentAsHTML(source.selection, inlineImage, options.SkipSanitization)
 	} else {
-		content = ae.extractContent(doc)
+		content = ae.extractContent(source.selection, options)
 	}
 
-	// Extract images using the optimized image extractor
-	imageExtractor := NewImageExtractor()
-	images := imageExtractor.ExtractImagesFromHTML(html, baseURL)
+	// Semantic blocks for clients that don't want to re-split the content
+	var paragraphs []string
+	if options.IncludeParagraphs {
+		for _, paragraph := range ExtractParagraphs(source.selection) {
+			if text := ae.sanitizeText(paragraph); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+		}
+	}
+
+	// Images placed in the paragraph stream, resolved like inline images
+	var imagePositions []models.ImagePosition
+	if options.IncludeImagePositions {
+		imageBase := ResolveBaseURL(doc, baseURL)
+		imagePositions = ExtractImagePositions(source.selection, func(img *goquery.Selection) string {
+			return imageExtractor.InlineImageURL(img, imageBase)
+		})
+	}
+
+	// Content grouped by heading, for per-section indexing or summaries
+	var sections []models.Section
+	if options.IncludeSections {
+		for _, section := range ExtractSections(source.selection) {
+			section.Heading = ae.sanitizeText(section.Heading)
+			section.Text = ae.sanitizeText(section.Text)
+			sections = append(sections, section)
+		}
+	}
+
+	// Quoted statements, for clients highlighting them apart from the flow. Readability
+	// strips the classes marking pullquotes and tweet embeds, so its pages are searched
+	// in the original content container instead.
+	var quotes []string
+	if options.IncludeQuotes {
+		scope := source.selection
+		if source.method == ExtractionMethodReadability {
+			scope = FindContentContainer(contentDoc)
+		}
+		for _, quote := range ExtractQuotes(scope) {
+			if text := ae.sanitizeText(quote); text != "" {
+				quotes = append(quotes, text)
+			}
+		}
+	}
+
+	// Reader comments come from the whole document, since readability drops them
+	var comments []string
+	if options.IncludeComments {
+		for _, comment := range ExtractComments(doc) {
+			if text := ae.sanitizeText(comment); text != "" {
+				comments = append(comments, text)
+			}
+		}
+	}
+
+	// Markdown rendering of the same subtree, so clients needing both don't scrape twice
+	var contentMarkdown string
+	if options.IncludeMarkdown {
+		contentMarkdown = ExtractMarkdown(source.selection, inlineImage)
+	}
+
+	// Inline microdata fills what the other sources missed on older CMSes
+	var microdata MicrodataArticle
+	if options.UseMicrodata {
+		microdata = ExtractMicrodata(doc)
+		if title == "" {
+			title = ae.sanitizeText(microdata.Headline)
+		}
+		if content == "" && options.StrictSelector == "" {
+			content = ae.sanitizeText(microdata.ArticleBody)
+		}
+	}
+
+	// Every title found, for clients preferring another source than Title's
+	var titleCandidates []models.TitleCandidate
+	if options.IncludeTitleCandidates {
+		titleCandidates = ae.titleCandidates(doc)
+		if headline := ae.sanitizeText(microdata.Headline); headline != "" {
+			titleCandidates = append(titleCandidates, models.TitleCandidate{Text: headline, Source: TitleSourceMicrodata})
+		}
+	}
+
+	// Client-rendered shells carry the article in their framework's inline state. A
+	// shell's own title is usually just the site name, so the state's title wins.
+	var appData AppDataArticle
+	if options.UseAppData && options.StrictSelector == "" && len(strings.Fields(content)) < AppDataMaxShellWords {
+		appData = ExtractAppData(doc)
+		if appData.Body != "" {
+			content = ae.sanitizeText(appData.Body)
+			title = ae.sanitizeText(appData.Title)
+			source.method = ExtractionMethodAppData
+			if options.IncludeTitleCandidates && title != "" {
+				titleCandidates = append([]models.TitleCandidate{{Text: title, Source: TitleSourceAppData}}, titleCandidates...)
+			}
+		}
+	}
+
+	// Web-component pages may keep the article in <template>s cloned in by scripts
+	if options.UseTemplates && options.StrictSelector == "" && len(strings.Fields(content)) < AppDataMaxShellWords {
+		if text := ExtractTemplateContent(doc); text != "" {
+			content = ae.sanitizeText(text)
+			source.method = ExtractionMethodTemplate
+		}
+	}
+
+	// Record the extraction decisions for debugging
+	var diagnostics *models.Diagnostics
+	if options.IncludeDiagnostics {
+		diagnostics = &models.Diagnostics{
+			ExtractionMethod: source.method,
+			ContentSelector:  source.selector,
+		}
+	}
+
+	// Extract images from the already-parsed document
+	mainImage, images := imageExtractor.extractImagesWithMain(doc, baseURL, diagnostics)
+
+	// Extract embedded videos if requested
+	var videos []models.VideoInfo
+	if options.IncludeVideos {
+		videos = NewVideoExtractor().ExtractVideosFromDocument(doc, ResolveBaseURL(doc, baseURL))
+	}
 
 	// Extract metadata if requested
 	var metadata models.ScrapeResponse
 	if options.IncludeMetadata {
-		metadata = ae.extractMetadataFromReadability(html)
+		metadata = ae.extractMetadataFromReadability(html, options)
+
+		// Readability already reads JSON-LD, so microdata comes after it
+		if metadata.Author == "" {
+			metadata.Author = ae.sanitizeText(microdata.Author)
+		}
+		if metadata.PublishDate == "" {
+			metadata.PublishDate = microdata.DatePublished
+		}
+		if metadata.PublishDate == "" {
+			metadata.PublishDate = appData.DatePublished
+		}
+		// Readability only takes the modified date from meta tags, not JSON-LD
+		if metadata.ModifiedDate == "" {
+			metadata.ModifiedDate = normalizeDate(JSONLDString(ExtractJSONLD(doc), "dateModified"))
+		}
+		if metadata.ModifiedDate == "" {
+			metadata.ModifiedDate = microdata.DateModified
+		}
+
+		// Individual authors; Author keeps the first for older clients
+		authorExtractor := NewAuthorExtractor()
+		metadata.Authors = authorExtractor.ExtractAuthors(doc, metadata.Author)
+		if len(metadata.Authors) > 0 {
+			metadata.Author = metadata.Authors[0]
+		}
+		metadata.AuthorImage = authorExtractor.ExtractAuthorImage(doc, baseURL)
 	}
 
 	// Calculate content quality metrics
-	quality := ScoreContentQuality(content, html)
+	qualityConfig := ae.qualityConfig
+	if options.QualityConfig != nil {
+		qualityConfig = *options.QualityConfig
+	}
+	quality := ScoreContentQualityWithConfig(content, html, signals, qualityConfig)
 
 	response := models.ScrapeResponse{
 		Title:       title,
 		Description: description,
 		Content:     content,
+		Paragraphs:  paragraphs,
+		Sections:    sections,
+		Quotes:      quotes,
+		Comments:    comments,
+		MainImage:   mainImage.URL,
 		Images:      images,
+		Videos:      videos,
+
+		ImagePositions: imagePositions,
+
 		Quality: models.Quality{
 			Score:              quality.Score,
 			TextToHTMLRatio:    quality.TextToHTMLRatio,
@@ -77,52 +269,248 @@ func (ae *ArticleExtractor) ExtractArticleWithOptions(html, baseURL string, opti
 		},
 	}
 
+	response.Slug = Slugify(title)
+	response.TitleCandidates = titleCandidates
+	if options.IncludeMainImageSize {
+		// Sizes declared in the markup; the scraper probes the image for missing ones
+		response.MainImageWidth, response.MainImageHeight = mainImage.Width, mainImage.Height
+	}
+	if options.IncludeThumbnail && response.MainImage != "" {
+		response.ThumbnailURL = imageExtractor.ThumbnailURL(response.MainImage)
+	}
+	if options.IncludeReadingLevel {
+		// HTML content is scored on its text
+		response.ReadingLevel = ScoreReadingLevel(ae.sanitizeText(content))
+	}
+	response.ContentMarkdown = contentMarkdown
+	response.Diagnostics = diagnostics
+	response.SelectorNotMatched = source.method == ExtractionMethodSelector && source.selection.Length() == 0
+	ae.setPageMetadata(&response, doc)
+	response.Paywalled = DetectPaywall(doc)
+	response.NextURL, response.PrevURL = FindSeriesLinks(doc, baseURL)
+	response.IndexPage = IsIndexPage(doc)
+	if response.IndexPage && options.IndexMode == IndexModeLinks {
+		response.ArticleLinks = FindArticleLinks(doc, baseURL)
+	}
+	if options.StripTrackingParams {
+		stripLinkTracking(&response)
+	}
+
+	// Hash content for cheap change detection
+	truncateContent(&response, options)
+	setContentHashes(&response)
+	setChunks(&response, options)
+
+	// Pass through raw JSON-LD if requested
+	if options.IncludeStructuredData {
+		response.StructuredData = ExtractJSONLD(doc)
+	}
+	if options.IncludeMetaTags {
+		response.MetaTags = FindAllMetaTags(doc)
+	}
+
+	// Page navigation, looked up in the whole document since readability drops it
+	if options.IncludeTOC {
+		response.TOC = ExtractTOC(doc, baseURL)
+	}
+
+	// Category hierarchy, from JSON-LD or the breadcrumb nav readability drops
+	if options.IncludeBreadcrumbs {
+		response.Breadcrumbs = ExtractBreadcrumbs(doc)
+	}
+
+	// Ingredients and steps, which the content text runs together
+	if options.IncludeRecipe {
+		response.Recipe = ExtractRecipe(doc)
+	}
+
 	// Add metadata fields if requested
 	if options.IncludeMetadata {
 		response.Author = metadata.Author
+		response.Authors = metadata.Authors
+		response.AuthorImage = metadata.AuthorImage
 		response.PublishDate = metadata.PublishDate
+		response.PublishDateLocal = localPublishDate(doc, ExtractJSONLD(doc), metadata.PublishDate)
+		response.ModifiedDate = metadata.ModifiedDate
 		response.Excerpt = metadata.Excerpt
 		response.ReadingTime = metadata.ReadingTime
 		response.Language = metadata.Language
 		response.TextLength = metadata.TextLength
 	}
 
-	return response
+	return response, nil
+}
+
+// setPageMetadata reads the page-level meta tags shared by full and preview extraction:
+// og:locale and its alternates for routing multilingual content, and the theme color
+// and og:image:alt for preview cards
+func (ae *ArticleExtractor) setPageMetadata(response *models.ScrapeResponse, doc *goquery.Document) {
+	response.Locale = FindMetaTag(doc, OGLocale, "")
+	response.AlternateLocales = FindMetaTags(doc, OGLocaleAlt)
+	response.ThemeColor = FindMetaTag(doc, "", MetaTheme)
+	response.ImageAlt = ae.sanitizeText(FindMetaTag(doc, OGImageAlt, TwitterAlt))
+}
+
+// hasDocumentBody reports whether the parsed document has body markup. The HTML parser
+// always synthesizes a <body>, so a body holding only nothing or a JSON blob means the
+// response wasn't really HTML.
+func hasDocumentBody(doc *goquery.Document) bool {
+	body := doc.Find("body")
+	if body.Length() == 0 {
+		return false
+	}
+	if body.Children().Length() > 0 {
+		return true
+	}
+
+	text := strings.TrimSpace(body.Text())
+	return text != "" && !strings.HasPrefix(text, "{") && !strings.HasPrefix(text, "[")
+}
+
+// truncateContent applies MaxContentLength to text content, before it is hashed and
+// chunked. Paragraphs, sections, Markdown and quality keep describing the full article.
+func truncateContent(response *models.ScrapeResponse, options ExtractionOptions) {
+	if options.MaxContentLength <= 0 || options.PreserveHTML {
+		return
+	}
+
+	var truncated bool
+	response.Content, truncated = TruncateText(response.Content, options.MaxContentLength)
+	response.Truncated = response.Truncated || truncated
+}
+
+// setChunks splits text content into ChunkSize chunks when requested; HTML content isn't chunked
+func setChunks(response *models.ScrapeResponse, options ExtractionOptions) {
+	if options.ChunkSize <= 0 || options.PreserveHTML {
+		return
+	}
+	response.Chunks = ChunkText(response.Content, options.ChunkSize)
+}
+
+// setContentHashes computes the change-detection hashes from the final title and content
+func setContentHashes(response *models.ScrapeResponse) {
+	response.ContentHash = HashContent(response.Content)
+	response.TitleContentHash = ""
+	if response.ContentHash != "" {
+		response.TitleContentHash = HashContent(response.Title + SingleNewline + response.Content)
+	}
 }
 
 // ExtractArticle extracts title, description, content, and images from HTML (backward compatibility)
 func (ae *ArticleExtractor) ExtractArticle(html, baseURL string) models.ScrapeResponse {
-	return ae.ExtractArticleWithOptions(html, baseURL, DefaultExtractionOptions())
+	response, _ := ae.ExtractArticleWithOptions(html, baseURL, DefaultExtractionOptions())
+	return response
+}
+
+// contentSource is the HTML subtree article content is extracted from
+type contentSource struct {
+	selection *goquery.Selection
+	method    string
+	selector  string // Selector that located the subtree; empty for readability
 }
 
-// extractContentAsHTML extracts content preserving HTML structure
-func (ae *ArticleExtractor) extractContentAsHTML(doc *goquery.Document) string {
-	// First, try to use readability algorithm for better content extraction
-	html, err := doc.Html()
-	if err == nil {
-		// Parse with readability, passing URL for better context
-		article, err := readability.FromReader(strings.NewReader(html), nil)
+// resolveContentSource picks the content subtree: the StrictSelector matches when set,
+// the whole body in full-page mode, otherwise readability's article with a selector-based fallback
+func (ae *ArticleExtractor) resolveContentSource(doc *goquery.Document, options ExtractionOptions) contentSource {
+	// Work on copies so the document stays intact for image and video extraction
+	if options.StrictSelector != "" {
+		scope := doc.Find(options.StrictSelector).Clone()
+		scope.Find(NonContentTags).Remove()
+		return contentSource{selection: scope, method: ExtractionMethodSelector, selector: options.StrictSelector}
+	}
+
+	if options.FullPage {
+		body := doc.Find("body").Clone()
+		body.Find(NonContentTags).Remove()
+		return contentSource{selection: body, method: ExtractionMethodFullPage, selector: "body"}
+	}
+
+	// Readability merges teasers on index pages; the largest one is the closest to an article
+	if options.IndexMode != IndexModeOff && IsIndexPage(doc) {
+		if articles := doc.Find("article"); articles.Length() > 0 {
+			return contentSource{selection: LargestByText(articles).Clone(), method: ExtractionMethodFallback, selector: "article"}
+		}
+	}
+
+	// Try readability algorithm first for better content extraction
+	if len(doc.Nodes) > 0 {
+		article, err := readability.FromDocument(doc.Nodes[0], nil)
 		if err == nil && article.Content != "" {
-			// Sanitize HTML content while preserving structure
-			return ae.htmlSanitizer.Sanitize(article.Content)
+			articleDoc, err := goquery.NewDocumentFromReader(strings.NewReader(article.Content))
+			if err == nil {
+				return contentSource{selection: articleDoc.Find("body"), method: ExtractionMethodReadability}
+			}
 		}
 	}
 
 	// Fallback to original selector-based approach if readability fails
-	return ae.extractContentFallbackAsHTML(doc)
+	container, selector := MatchContentContainer(doc)
+	return contentSource{selection: container.Clone(), method: ExtractionMethodFallback, selector: selector}
 }
 
-// extractContentFallbackAsHTML provides HTML-based content extraction fallback
-func (ae *ArticleExtractor) extractContentFallbackAsHTML(doc *goquery.Document) string {
-	// Find the main content container
-	contentElement := FindContentContainer(doc)
+// extractContent converts the content subtree to structured text
+func (ae *ArticleExtractor) extractContent(selection *goquery.Selection, options ExtractionOptions) string {
+	// Line-broken text is already filtered block by block
+	if options.PreserveLineBreaks {
+		if content := ExtractLineBrokenText(selection, TextElements, options.MarkHeadings); content != "" {
+			return ae.sanitizeText(content)
+		}
+	}
+
+	// Extract structured text
+	content := ExtractTextFromElements(selection, TextElements, options.MarkHeadings)
 
-	// Get HTML content and sanitize it
-	htmlContent, err := contentElement.Html()
+	// If no structured content found, extract all text
+	if content == "" {
+		content = ExtractFallbackText(selection)
+	}
+
+	// Clean up whitespace and remove noise; marked headings are kept however short
+	if options.MarkHeadings {
+		content = cleanTextLines(content, IsMarkedHeading)
+	} else {
+		content = CleanTextContent(content)
+	}
+	return ae.sanitizeText(content)
+}
+
+// inlineImageFunc resolves content images for inline rendering when KeepInlineImages is set
+func inlineImageFunc(imageExtractor *ImageExtractor, doc *goquery.Document, baseURL string, options ExtractionOptions) InlineImageFunc {
+	if !options.KeepInlineImages {
+		return nil
+	}
+
+	imageBase := ResolveBaseURL(doc, baseURL)
+	return func(img *goquery.Selection) string {
+		return imageExtractor.InlineImageURL(img, imageBase)
+	}
+}
+
+// extractContentAsHTML returns the content subtree as sanitized HTML, preserving structure.
+// With imageURL set, images get absolute src URLs and unresolvable ones are removed.
+// skipSanitization returns the HTML as extracted, see ExtractionOptions.SkipSanitization.
+func (ae *ArticleExtractor) extractContentAsHTML(selection *goquery.Selection, imageURL InlineImageFunc, skipSanitization bool) string {
+	if imageURL != nil {
+		selection = selection.Clone()
+		selection.Find(ImageTags).Each(func(i int, img *goquery.Selection) {
+			src := imageURL(img)
+			if src == "" {
+				img.Remove()
+				return
+			}
+			img.SetAttr("src", src)
+			img.RemoveAttr("srcset")
+		})
+	}
+
+	htmlContent, err := selection.Html()
 	if err != nil {
 		return ""
 	}
 
+	if skipSanitization {
+		return strings.TrimSpace(htmlContent)
+	}
 	return ae.htmlSanitizer.Sanitize(htmlContent)
 }
 
@@ -139,33 +527,31 @@ func (ae *ArticleExtractor) sanitizeText(text string) string {
 	return CleanWhitespace(sanitized)
 }
 
-// extractTitle extracts the page title with fallback strategies
+// extractTitle extracts the page title with fallback strategies: the first of the
+// title candidates
 func (ae *ArticleExtractor) extractTitle(doc *goquery.Document) string {
-	// Try Open Graph title first
-	if title := FindMetaTag(doc, OGTitle, ""); title != "" {
-		return ae.sanitizeText(title)
-	}
-
-	// Try Twitter card title
-	if title := FindMetaTag(doc, "", TwitterTitle); title != "" {
-		return ae.sanitizeText(title)
+	if candidates := ae.titleCandidates(doc); len(candidates) > 0 {
+		return candidates[0].Text
 	}
+	return ""
+}
 
-	// Try h1 tag
-	var title string
-	doc.Find("h1").First().Each(func(i int, s *goquery.Selection) {
-		title = strings.TrimSpace(s.Text())
-	})
-	if title != "" {
-		return ae.sanitizeText(title)
+// titleCandidates lists the page's titles in order of preference: Open Graph, Twitter
+// card, the first h1, then the title tag
+func (ae *ArticleExtractor) titleCandidates(doc *goquery.Document) []models.TitleCandidate {
+	var candidates []models.TitleCandidate
+	add := func(text, source string) {
+		if text = ae.sanitizeText(strings.TrimSpace(text)); text != "" {
+			candidates = append(candidates, models.TitleCandidate{Text: text, Source: source})
+		}
 	}
 
-	// Try title tag as last resort
-	doc.Find("title").Each(func(i int, s *goquery.Selection) {
-		title = strings.TrimSpace(s.Text())
-	})
+	add(FindMetaTag(doc, OGTitle, ""), TitleSourceOpenGraph)
+	add(FindMetaTag(doc, "", TwitterTitle), TitleSourceTwitter)
+	add(doc.Find("h1").First().Text(), TitleSourceHeading)
+	add(doc.Find("title").Not("svg title").Last().Text(), TitleSourceTitleTag) // <svg> titles are icon labels
 
-	return ae.sanitizeText(title)
+	return candidates
 }
 
 // extractDescription extracts the page description with fallback strategies
@@ -193,91 +579,35 @@ func (ae *ArticleExtractor) extractDescription(doc *goquery.Document) string {
 	return ""
 }
 
-// extractContent extracts the main article content using readability algorithm
-func (ae *ArticleExtractor) extractContent(doc *goquery.Document) string {
-	// First, try to use readability algorithm for better content extraction
-	html, err := doc.Html()
-	if err == nil {
-		// Parse with readability, passing URL for better context
-		article, err := readability.FromReader(strings.NewReader(html), nil)
-		if err == nil && article.Content != "" {
-			// Convert readability's HTML content to structured text
-			return ae.convertHTMLToStructuredText(article.Content)
-		}
-	}
-
-	// Fallback to original selector-based approach if readability fails
-	return ae.extractContentFallback(doc)
-}
-
-// convertHTMLToStructuredText converts HTML content to structured text
-func (ae *ArticleExtractor) convertHTMLToStructuredText(htmlContent string) string {
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
-	if err != nil {
-		return ae.sanitizeText(htmlContent)
-	}
-
-	// Extract structured text
-	content := ExtractTextFromElements(doc.Selection, TextElements)
-
-	// If no structured content found, extract all text
-	if content == "" {
-		content = ExtractFallbackText(doc.Selection)
-	}
-
-	// Clean up whitespace and remove noise
-	content = CleanTextContent(content)
-	return ae.sanitizeText(content)
-}
-
-// extractContentFallback provides the original selector-based content extraction
-func (ae *ArticleExtractor) extractContentFallback(doc *goquery.Document) string {
-	// Find the main content container
-	contentElement := FindContentContainer(doc)
-
-	// Extract structured text from the container
-	content := ExtractTextFromElements(contentElement, TextElements)
-
-	// If no structured content found, extract all text
-	if content == "" {
-		content = ExtractFallbackText(contentElement)
-	}
-
-	// Clean up whitespace and remove noise
-	content = CleanTextContent(content)
-	return ae.sanitizeText(content)
-}
-
 // extractMetadataFromReadability extracts additional metadata using readability
-func (ae *ArticleExtractor) extractMetadataFromReadability(html string) models.ScrapeResponse {
+func (ae *ArticleExtractor) extractMetadataFromReadability(html string, options ExtractionOptions) models.ScrapeResponse {
 	article, err := readability.FromReader(strings.NewReader(html), nil)
 	if err != nil {
 		return models.ScrapeResponse{}
 	}
 
-	// Calculate reading time (average 200 words per minute, but we'll use character count)
-	readingTime := 0
-	if article.Length > 0 {
-		// Estimate reading time based on character count (roughly 5 chars per word, 200 words per minute)
-		readingTime = int(article.Length / 1000) // characters / 1000 chars per minute
-		if readingTime < 1 {
-			readingTime = 1
-		}
-	}
+	// Calculate reading time in words (or characters for CJK) per minute
+	readingTime := EstimateReadingTime(article.TextContent, article.Language,
+		options.WordsPerMinute, options.CharsPerMinute)
 
-	// Convert publish date to string
+	// Convert publish and modified dates to strings
 	publishDate := ""
 	if article.PublishedTime != nil {
-		publishDate = article.PublishedTime.Format("2006-01-02T15:04:05Z")
+		publishDate = article.PublishedTime.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	modifiedDate := ""
+	if article.ModifiedTime != nil {
+		modifiedDate = article.ModifiedTime.UTC().Format("2006-01-02T15:04:05Z")
 	}
 
 	return models.ScrapeResponse{
-		Author:      article.Byline,
-		PublishDate: publishDate,
-		Excerpt:     article.Excerpt,
-		ReadingTime: readingTime,
-		Language:    article.Language,
-		TextLength:  article.Length,
+		Author:       article.Byline,
+		PublishDate:  publishDate,
+		ModifiedDate: modifiedDate,
+		Excerpt:      article.Excerpt,
+		ReadingTime:  readingTime,
+		Language:     article.Language,
+		TextLength:   article.Length,
 	}
 }
 
@@ -4,7 +4,10 @@ package scraper
 import (
 	"strings"
 
+	"extract-html-scraper/internal/models"
+
 	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
 )
 
 // FindMetaTag searches for a meta tag with the given property or name
@@ -40,8 +43,50 @@ func FindMetaTag(doc *goquery.Document, property, name string) string {
 	return value
 }
 
-// ExtractTextFromElements extracts text content preserving structure from HTML elements
-func ExtractTextFromElements(selection *goquery.Selection, elements string) string {
+// FindMetaTags returns the values of every meta tag with the given property, in document order
+func FindMetaTags(doc *goquery.Document, property string) []string {
+	var values []string
+
+	doc.Find("meta").Each(func(i int, s *goquery.Selection) {
+		if prop, exists := s.Attr("property"); !exists || prop != property {
+			return
+		}
+		if content := strings.TrimSpace(s.AttrOr("content", "")); content != "" {
+			values = append(values, content)
+		}
+	})
+
+	return values
+}
+
+// FindAllMetaTags maps the property or name of every <meta> in the head to its content.
+// Keys are lowercased, and the first tag wins when a key repeats.
+func FindAllMetaTags(doc *goquery.Document) map[string]string {
+	tags := make(map[string]string)
+
+	doc.Find("head meta[content]").Each(func(i int, s *goquery.Selection) {
+		key := s.AttrOr("property", "")
+		if key == "" {
+			key = s.AttrOr("name", "")
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			return
+		}
+		if _, seen := tags[key]; !seen {
+			tags[key] = strings.TrimSpace(s.AttrOr("content", ""))
+		}
+	})
+
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+// ExtractTextFromElements extracts text content preserving structure from HTML elements.
+// markHeadings prefixes headings with HeadingMarker, on a single line.
+func ExtractTextFromElements(selection *goquery.Selection, elements string, markHeadings bool) string {
 	var content strings.Builder
 
 	selection.Find(elements).Each(func(i int, s *goquery.Selection) {
@@ -56,6 +101,9 @@ func ExtractTextFromElements(selection *goquery.Selection, elements string) stri
 			if content.Len() > 0 {
 				content.WriteString(DoubleNewline)
 			}
+			if markHeadings {
+				text = HeadingMarker(tagName) + strings.Join(strings.Fields(text), SingleSpace)
+			}
 			content.WriteString(text)
 			content.WriteString(SingleNewline)
 		case "p", "li", "blockquote":
@@ -69,6 +117,199 @@ func ExtractTextFromElements(selection *goquery.Selection, elements string) stri
 	return content.String()
 }
 
+// ExtractLineBrokenText is ExtractTextFromElements keeping <br> line breaks inside each
+// block as single newlines, for poetry, lyrics and addresses. Whitespace within a line
+// is collapsed, and blocks of MinContentLineLength characters or less are dropped as UI
+// noise here, since CleanTextContent would drop short verse lines one by one. Marked
+// headings are kept whatever their length.
+func ExtractLineBrokenText(selection *goquery.Selection, elements string, markHeadings bool) string {
+	var content strings.Builder
+
+	selection.Find(elements).Each(func(i int, s *goquery.Selection) {
+		tagName := goquery.NodeName(s)
+		isHeading := s.Is(HeadingTags)
+
+		lines := blockLines(s)
+		if isHeading && markHeadings {
+			if len(lines) == 0 {
+				return
+			}
+			lines = []string{HeadingMarker(tagName) + strings.Join(lines, SingleSpace)}
+		} else if len(strings.Join(lines, SingleSpace)) <= MinContentLineLength {
+			return
+		}
+		text := strings.Join(lines, SingleNewline)
+
+		switch tagName {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			if content.Len() > 0 {
+				content.WriteString(DoubleNewline)
+			}
+			content.WriteString(text)
+			content.WriteString(SingleNewline)
+		case "p", "li", "blockquote":
+			if content.Len() > 0 {
+				content.WriteString(SingleNewline)
+			}
+			content.WriteString(text)
+		}
+	})
+
+	return content.String()
+}
+
+// HeadingMarker returns the Markdown-style marker for a heading tag: "# " for h1
+// through "###### " for h6
+func HeadingMarker(tagName string) string {
+	return strings.Repeat("#", int(tagName[1]-'0')) + SingleSpace
+}
+
+// IsMarkedHeading reports whether a text content line starts with a HeadingMarker
+func IsMarkedHeading(line string) bool {
+	marker := strings.TrimLeft(line, "#")
+	level := len(line) - len(marker)
+	return level >= 1 && level <= 6 && strings.HasPrefix(marker, SingleSpace) && strings.TrimSpace(marker) != ""
+}
+
+// blockLines splits a block's text at its <br> elements, collapsing whitespace within
+// each line and dropping empty lines
+func blockLines(s *goquery.Selection) []string {
+	var lines []string
+	var line strings.Builder
+	endLine := func() {
+		if text := strings.Join(strings.Fields(line.String()), SingleSpace); text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		switch {
+		case node.Type == html.TextNode:
+			line.WriteString(node.Data)
+		case node.Type == html.ElementNode && node.Data == "br":
+			endLine()
+		case node.Type == html.ElementNode && (node.Data == "script" || node.Data == "style"):
+		default:
+			for child := node.FirstChild; child != nil; child = child.NextSibling {
+				walk(child)
+			}
+		}
+	}
+	for _, node := range s.Nodes {
+		walk(node)
+	}
+	endLine()
+
+	return lines
+}
+
+// ExtractParagraphs returns the text of each paragraph-level block in document order.
+// Blocks wrapping other blocks (a <blockquote> of <p>s) yield their inner blocks instead.
+func ExtractParagraphs(selection *goquery.Selection) []string {
+	var paragraphs []string
+
+	selection.Find(ParagraphElements).Each(func(i int, s *goquery.Selection) {
+		if s.Find(ParagraphElements).Length() > 0 {
+			return
+		}
+
+		text := CleanWhitespace(strings.Join(strings.Fields(s.Text()), SingleSpace))
+		if text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+
+	return paragraphs
+}
+
+// ExtractImagePositions lists the images in the content with the number of paragraphs
+// before each, counting blocks as ExtractParagraphs does; an image inside a paragraph
+// counts as following it. Images imageURL can't resolve are skipped.
+func ExtractImagePositions(selection *goquery.Selection, imageURL InlineImageFunc) []models.ImagePosition {
+	var positions []models.ImagePosition
+	paragraphs := 0
+
+	selection.Find(ParagraphElements + ", " + ImageTags).Each(func(i int, s *goquery.Selection) {
+		if s.Is(ImageTags) {
+			if src := imageURL(s); src != "" {
+				positions = append(positions, models.ImagePosition{URL: src, ParagraphIndex: paragraphs})
+			}
+			return
+		}
+
+		if s.Find(ParagraphElements).Length() > 0 {
+			return
+		}
+		if strings.TrimSpace(s.Text()) != "" {
+			paragraphs++
+		}
+	})
+
+	return positions
+}
+
+// ExtractSections groups the paragraph-level blocks under the heading preceding them.
+// Blocks before the first heading form an intro section with no heading and level 0.
+func ExtractSections(selection *goquery.Selection) []models.Section {
+	var sections []models.Section
+	var current models.Section
+	var paragraphs []string
+
+	flush := func() {
+		if current.Heading != "" || len(paragraphs) > 0 {
+			current.Text = strings.Join(paragraphs, DoubleNewline)
+			sections = append(sections, current)
+		}
+		paragraphs = nil
+	}
+
+	selection.Find(TextElements).Each(func(i int, s *goquery.Selection) {
+		if s.Find(TextElements).Length() > 0 {
+			return
+		}
+
+		text := CleanWhitespace(strings.Join(strings.Fields(s.Text()), SingleSpace))
+		if text == "" {
+			return
+		}
+
+		if s.Is(HeadingTags) {
+			flush()
+			current = models.Section{Heading: text, Level: int(goquery.NodeName(s)[1] - '0')}
+			return
+		}
+		paragraphs = append(paragraphs, text)
+	})
+	flush()
+
+	return sections
+}
+
+// ExtractQuotes returns the text of each blockquote and pullquote in the content, in
+// document order. Quotes nested in another quote belong to it, and repeated text (a
+// pullquote restating a blockquote) is kept once.
+func ExtractQuotes(selection *goquery.Selection) []string {
+	var quotes []string
+	seen := make(map[string]bool)
+
+	selection.Find(QuoteSelectors).Each(func(i int, s *goquery.Selection) {
+		if s.Is(QuoteEmbedSelectors) || s.ParentsFiltered(QuoteSelectors).Length() > 0 {
+			return
+		}
+
+		text := CleanWhitespace(strings.Join(strings.Fields(s.Text()), SingleSpace))
+		if text == "" || seen[text] {
+			return
+		}
+		seen[text] = true
+		quotes = append(quotes, text)
+	})
+
+	return quotes
+}
+
 // ExtractFallbackText extracts all text content when structured extraction fails
 func ExtractFallbackText(selection *goquery.Selection) string {
 	// Remove non-content elements
@@ -81,17 +322,29 @@ func ExtractFallbackText(selection *goquery.Selection) string {
 
 // FindContentContainer finds the main content container using common selectors
 func FindContentContainer(doc *goquery.Document) *goquery.Selection {
+	container, _ := MatchContentContainer(doc)
+	return container
+}
+
+// MatchContentContainer finds the main content container along with the selector that matched it
+func MatchContentContainer(doc *goquery.Document) (*goquery.Selection, string) {
 	selectors := strings.Split(ContentSelectors, ", ")
 
 	for _, selector := range selectors {
 		selector = strings.TrimSpace(selector)
-		if doc.Find(selector).Length() > 0 {
-			return doc.Find(selector).First()
+		matches := doc.Find(selector)
+		if matches.Length() == 0 {
+			continue
 		}
+		// Several <article>s are usually the story plus related teasers
+		if selector == "article" {
+			return LargestByText(matches), selector
+		}
+		return matches.First(), selector
 	}
 
 	// Fallback to body
-	return doc.Find("body")
+	return doc.Find("body"), "body"
 }
 
 // ExtractDescriptionFromParagraph extracts description from first suitable paragraph
@@ -107,3 +360,13 @@ func ExtractDescriptionFromParagraph(doc *goquery.Document) string {
 
 	return description
 }
+
+// firstAttr returns the first non-empty attribute value among the given names
+func firstAttr(s *goquery.Selection, names ...string) string {
+	for _, name := range names {
+		if value, exists := s.Attr(name); exists && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
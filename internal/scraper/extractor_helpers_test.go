@@ -0,0 +1,291 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+
+	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestExtractParagraphs(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []string
+	}{
+		{
+			name: "paragraphs and list items",
+			html: `<p>First   paragraph.</p><ul><li>One item</li><li>Two items</li></ul><p>Last one.</p>`,
+			want: []string{"First paragraph.", "One item", "Two items", "Last one."},
+		},
+		{
+			name: "blockquote yields its inner paragraphs",
+			html: `<blockquote><p>Quoted once.</p><p>Quoted twice.</p></blockquote>`,
+			want: []string{"Quoted once.", "Quoted twice."},
+		},
+		{
+			name: "empty blocks skipped",
+			html: `<p> </p><p>Only text.</p>`,
+			want: []string{"Only text."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<article>"+tt.html+"</article>")
+			if got := ExtractParagraphs(doc.Find("article")); !equalStrings(got, tt.want) {
+				t.Errorf("ExtractParagraphs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSections(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []models.Section
+	}{
+		{
+			name: "intro and headed sections",
+			html: `<p>Intro.</p><h2>Costs</h2><p>It costs a lot.</p><p>Taxes rise.</p><h3>Funding</h3><p>Grants help.</p>`,
+			want: []models.Section{
+				{Level: 0, Text: "Intro."},
+				{Heading: "Costs", Level: 2, Text: "It costs a lot.\n\nTaxes rise."},
+				{Heading: "Funding", Level: 3, Text: "Grants help."},
+			},
+		},
+		{
+			name: "heading without paragraphs kept",
+			html: `<h2>Empty</h2><h2>Full</h2><p>Body.</p>`,
+			want: []models.Section{
+				{Heading: "Empty", Level: 2},
+				{Heading: "Full", Level: 2, Text: "Body."},
+			},
+		},
+		{
+			name: "no headings",
+			html: `<p>One.</p><p>Two.</p>`,
+			want: []models.Section{{Text: "One.\n\nTwo."}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<article>"+tt.html+"</article>")
+			got := ExtractSections(doc.Find("article"))
+			if len(got) != len(tt.want) {
+				t.Fatalf("ExtractSections() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("section %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExtractQuotes(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []string
+	}{
+		{
+			name: "blockquote and pullquote",
+			html: `<p>Intro.</p><blockquote><p>We will not back down.</p></blockquote><div class="wp-block-pullquote"><p>Buses first.</p></div>`,
+			want: []string{"We will not back down.", "Buses first."},
+		},
+		{
+			name: "nested quote belongs to its parent",
+			html: `<blockquote>Outer <blockquote>inner</blockquote></blockquote>`,
+			want: []string{"Outer inner"},
+		},
+		{
+			name: "repeated pullquote kept once",
+			html: `<blockquote>Buses first.</blockquote><aside class="pull-quote">Buses first.</aside>`,
+			want: []string{"Buses first."},
+		},
+		{
+			name: "tweet embed skipped",
+			html: `<blockquote class="twitter-tweet"><p>Just landed!</p></blockquote>`,
+		},
+		{
+			name: "no quotes",
+			html: `<p>Plain article.</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<article>"+tt.html+"</article>")
+			if got := ExtractQuotes(doc.Find("article")); !equalStrings(got, tt.want) {
+				t.Errorf("ExtractQuotes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractLineBrokenText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "poem lines kept",
+			html: `<p>Whose woods these are I think I know.<br>His house is in the village   though;<br/>
+He will not see me stopping here</p>`,
+			want: "Whose woods these are I think I know.\nHis house is in the village though;\nHe will not see me stopping here",
+		},
+		{
+			name: "empty lines dropped",
+			html: `<p>123 Main Street<br><br>Springfield, IL 62701</p>`,
+			want: "123 Main Street\nSpringfield, IL 62701",
+		},
+		{
+			name: "heading then stanza",
+			html: `<h2>Stopping by Woods on a Snowy Evening</h2><p>The woods are lovely, dark and deep,<br>But I have promises to keep</p>`,
+			want: "Stopping by Woods on a Snowy Evening\n\nThe woods are lovely, dark and deep,\nBut I have promises to keep",
+		},
+		{
+			name: "short block dropped",
+			html: `<p>Share<br>Tweet</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<article>"+tt.html+"</article>")
+			if got := ExtractLineBrokenText(doc.Find("article"), TextElements, false); got != tt.want {
+				t.Errorf("ExtractLineBrokenText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingMarker(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"h1", "# "},
+		{"h2", "## "},
+		{"h6", "###### "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := HeadingMarker(tt.tag); got != tt.want {
+				t.Errorf("HeadingMarker(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMarkedHeading(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"# Title", true},
+		{"### Background", true},
+		{"###### Deep", true},
+		{"####### Too deep", false},
+		{"#hashtag", false},
+		{"## ", false},
+		{"Plain line", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := IsMarkedHeading(tt.line); got != tt.want {
+				t.Errorf("IsMarkedHeading(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractImagePositions(t *testing.T) {
+	srcURL := func(img *goquery.Selection) string { return img.AttrOr("src", "") }
+
+	tests := []struct {
+		name string
+		html string
+		want []models.ImagePosition
+	}{
+		{
+			name: "between paragraphs 2 and 3",
+			html: `<p>One.</p><p>Two.</p><figure><img src="a.jpg"></figure><p>Three.</p>`,
+			want: []models.ImagePosition{{URL: "a.jpg", ParagraphIndex: 2}},
+		},
+		{
+			name: "above the first paragraph and inside one",
+			html: `<img src="hero.jpg"><p>One.</p><p>Two <img src="inline.jpg"></p>`,
+			want: []models.ImagePosition{{URL: "hero.jpg", ParagraphIndex: 0}, {URL: "inline.jpg", ParagraphIndex: 2}},
+		},
+		{
+			name: "list items and wrappers counted like paragraphs",
+			html: `<blockquote><p>Quoted.</p></blockquote><ul><li>Item</li></ul><p> </p><amp-img src="amp.jpg"></amp-img>`,
+			want: []models.ImagePosition{{URL: "amp.jpg", ParagraphIndex: 2}},
+		},
+		{
+			name: "unresolvable image skipped",
+			html: `<p>One.</p><img data-src="lazy.jpg">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<article>"+tt.html+"</article>")
+			if got := ExtractImagePositions(doc.Find("article"), srcURL); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractImagePositions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindAllMetaTags(t *testing.T) {
+	tests := []struct {
+		name string
+		head string
+		want map[string]string
+	}{
+		{
+			name: "property and name tags",
+			head: `<meta property="og:title" content="Transit Plan Approved">
+<meta name="twitter:card" content="summary_large_image">
+<meta property="article:section" content="Politics">
+<meta name="robots" content="max-image-preview:large">
+<meta name="x-custom-id" content=" 4417 ">`,
+			want: map[string]string{
+				"og:title":        "Transit Plan Approved",
+				"twitter:card":    "summary_large_image",
+				"article:section": "Politics",
+				"robots":          "max-image-preview:large",
+				"x-custom-id":     "4417",
+			},
+		},
+		{
+			name: "lowercased and first wins",
+			head: `<meta property="OG:Type" content="article"><meta property="og:type" content="website">`,
+			want: map[string]string{"og:type": "article"},
+		},
+		{
+			name: "charset and http-equiv skipped",
+			head: `<meta charset="utf-8"><meta http-equiv="refresh" content="30">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, `<html><head>`+tt.head+`</head><body><meta name="body-tag" content="ignored"></body></html>`)
+			if got := FindAllMetaTags(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAllMetaTags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,659 @@
+package scraper
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"extract-html-scraper/internal/models"
+)
+
+// multiSectionPage has an article plus a sidebar and footer that aren't part of it
+const multiSectionPage = `<html><head><title>Transit Plan Approved</title></head><body>
+<header><p>Site navigation and sign-in links live here for every page.</p></header>
+<main><article>
+<h1>Transit Plan Approved</h1>
+<p>The council approved the new transit plan on Tuesday after months of public hearings and debate among residents.</p>
+<p>Officials said the first bus lanes will open next spring, with more routes following in the autumn of next year.</p>
+<p>Residents who spoke at the meeting were broadly supportive, though some raised concerns about parking downtown.</p>
+<p>The plan is funded by a mix of state grants and a small increase in the regional sales tax approved last year.</p>
+</article></main>
+<aside><p>Trending elsewhere: the best pizza places in town, ranked by our readers this week.</p></aside>
+<footer><p>Copyright notice and newsletter signup for the local paper appear in this footer.</p></footer>
+</body></html>`
+
+func TestFullPageExtraction(t *testing.T) {
+	tests := []struct {
+		name        string
+		fullPage    bool
+		wantSidebar bool
+	}{
+		{"default keeps to the article", false, false},
+		{"full page includes the sidebar", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.FullPage = tt.fullPage
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(multiSectionPage, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			if !strings.Contains(result.Content, "first bus lanes") {
+				t.Errorf("content misses the article body: %q", result.Content)
+			}
+			if got := strings.Contains(result.Content, "best pizza places"); got != tt.wantSidebar {
+				t.Errorf("sidebar in content = %v, want %v: %q", got, tt.wantSidebar, result.Content)
+			}
+		})
+	}
+}
+
+func TestIncludeParagraphs(t *testing.T) {
+	tests := []struct {
+		name    string
+		include bool
+		want    int
+	}{
+		{"off", false, 0},
+		{"one entry per article paragraph", true, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeParagraphs = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(multiSectionPage, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if len(result.Paragraphs) != tt.want {
+				t.Fatalf("paragraphs = %q, want %d", result.Paragraphs, tt.want)
+			}
+			if tt.want > 0 && !strings.HasPrefix(result.Paragraphs[0], "The council approved") {
+				t.Errorf("first paragraph = %q", result.Paragraphs[0])
+			}
+		})
+	}
+}
+
+func TestPublishAndModifiedDates(t *testing.T) {
+	tests := []struct {
+		name         string
+		head         string
+		wantPublish  string
+		wantModified string
+	}{
+		{
+			name:         "json-ld dates",
+			head:         `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","datePublished":"2024-03-05T08:30:00Z","dateModified":"2024-03-07T16:00:00Z"}</script>`,
+			wantPublish:  "2024-03-05T08:30:00Z",
+			wantModified: "2024-03-07T16:00:00Z",
+		},
+		{
+			name:         "meta tags",
+			head:         `<meta property="article:published_time" content="2024-03-05T08:30:00Z"><meta property="article:modified_time" content="2024-03-06T09:00:00Z">`,
+			wantPublish:  "2024-03-05T08:30:00Z",
+			wantModified: "2024-03-06T09:00:00Z",
+		},
+		{
+			name:        "never updated",
+			head:        `<meta property="article:published_time" content="2024-03-05T08:30:00Z">`,
+			wantPublish: "2024-03-05T08:30:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := strings.Replace(multiSectionPage, "</head>", tt.head+"</head>", 1)
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", DefaultExtractionOptions())
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if result.PublishDate != tt.wantPublish || result.ModifiedDate != tt.wantModified {
+				t.Errorf("published %q, modified %q, want %q, %q", result.PublishDate, result.ModifiedDate, tt.wantPublish, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestExtractEmptyDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		wantErr bool
+	}{
+		{"json served as html", `{"error": "rate limited", "retryAfter": 30}`, true},
+		{"empty body", ``, true},
+		{"whitespace", "  \n\t ", true},
+		{"empty html shell", `<html><head><title>Loading</title></head><body></body></html>`, true},
+		{"article", multiSectionPage, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArticleExtractor().ExtractArticleWithOptions(tt.html, "https://example.com/api", DefaultExtractionOptions())
+			if got := errors.Is(err, ErrEmptyDocument); got != tt.wantErr {
+				t.Fatalf("error = %v, want ErrEmptyDocument %v", err, tt.wantErr)
+			}
+			var extractErr *models.ContentExtractionError
+			if tt.wantErr && !errors.As(err, &extractErr) {
+				t.Errorf("error %T is not a ContentExtractionError", err)
+			}
+		})
+	}
+}
+
+func TestLocales(t *testing.T) {
+	tests := []struct {
+		name          string
+		head          string
+		wantLocale    string
+		wantAlternate []string
+	}{
+		{
+			name:          "locale and two alternates",
+			head:          `<meta property="og:locale" content="en_US"><meta property="og:locale:alternate" content="fr_FR"><meta property="og:locale:alternate" content="de_DE">`,
+			wantLocale:    "en_US",
+			wantAlternate: []string{"fr_FR", "de_DE"},
+		},
+		{
+			name:       "locale only",
+			head:       `<meta property="og:locale" content="es_ES">`,
+			wantLocale: "es_ES",
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := strings.Replace(multiSectionPage, "</head>", tt.head+"</head>", 1)
+			for _, preview := range []bool{false, true} {
+				options := DefaultExtractionOptions()
+				options.Preview = preview
+				result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+				if err != nil {
+					t.Fatalf("extract: %v", err)
+				}
+				if result.Locale != tt.wantLocale || !equalStrings(result.AlternateLocales, tt.wantAlternate) {
+					t.Errorf("preview %v: locale %q, alternates %q, want %q, %q", preview, result.Locale, result.AlternateLocales, tt.wantLocale, tt.wantAlternate)
+				}
+			}
+		})
+	}
+}
+
+func TestStrictSelector(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "<p>Officials said", `<div class="related"><p>Related: council members debate the new parking rules downtown.</p></div><p>Officials said`, 1)
+	page = strings.Replace(page, "<article>", `<article itemscope><meta itemprop="articleBody" content="Microdata body that must not leak into strict results.">`, 1)
+
+	tests := []struct {
+		name        string
+		selector    string
+		wantContent []string
+		wantAbsent  []string
+	}{
+		{
+			name:        "selector scopes the content",
+			selector:    "article > p",
+			wantContent: []string{"first bus lanes", "state grants"},
+			wantAbsent:  []string{"Related:", "best pizza places", "Microdata body"},
+		},
+		{
+			name:       "no match leaves content empty",
+			selector:   ".story-body",
+			wantAbsent: []string{"first bus lanes", "Microdata body"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.StrictSelector = tt.selector
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			for _, want := range tt.wantContent {
+				if !strings.Contains(result.Content, want) {
+					t.Errorf("content misses %q: %q", want, result.Content)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(result.Content, absent) {
+					t.Errorf("content leaks %q: %q", absent, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewCardMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		head      string
+		wantTheme string
+		wantAlt   string
+	}{
+		{
+			name:      "theme color and og alt",
+			head:      `<meta name="theme-color" content="#0a7cff"><meta property="og:image:alt" content="Bus at a new stop">`,
+			wantTheme: "#0a7cff",
+			wantAlt:   "Bus at a new stop",
+		},
+		{
+			name:    "twitter alt fallback",
+			head:    `<meta name="twitter:image:alt" content="Council chamber">`,
+			wantAlt: "Council chamber",
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := strings.Replace(multiSectionPage, "</head>", tt.head+"</head>", 1)
+			for _, preview := range []bool{false, true} {
+				options := DefaultExtractionOptions()
+				options.Preview = preview
+				result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+				if err != nil {
+					t.Fatalf("extract: %v", err)
+				}
+				if result.ThemeColor != tt.wantTheme || result.ImageAlt != tt.wantAlt {
+					t.Errorf("preview %v: theme %q, alt %q, want %q, %q", preview, result.ThemeColor, result.ImageAlt, tt.wantTheme, tt.wantAlt)
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "<p>Residents who spoke", `<img src="https://cdn.example.com/bus.jpg" width="1200" height="800"><img src="https://cdn.example.com/thumb.jpg" width="120" height="90"><p>Residents who spoke`, 1)
+
+	tests := []struct {
+		name         string
+		configure    func(*ExtractionOptions)
+		wantMethod   string
+		wantSelector string
+	}{
+		{"readability", func(o *ExtractionOptions) {}, ExtractionMethodReadability, ""},
+		{"full page", func(o *ExtractionOptions) { o.FullPage = true }, ExtractionMethodFullPage, "body"},
+		{"strict selector", func(o *ExtractionOptions) { o.StrictSelector = "main" }, ExtractionMethodSelector, "main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeDiagnostics = true
+			tt.configure(&options)
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			d := result.Diagnostics
+			if d == nil {
+				t.Fatal("no diagnostics")
+			}
+			if d.ExtractionMethod != tt.wantMethod || d.ContentSelector != tt.wantSelector || d.BrowserUsed {
+				t.Errorf("diagnostics = %+v, want method %q selector %q", d, tt.wantMethod, tt.wantSelector)
+			}
+			if d.ImageCandidates != 2 || d.ImagesKept != 1 || d.ImageRejections[RejectImageSize] != 1 {
+				t.Errorf("image diagnostics = %+v, want one of two kept and one rejected for size", d)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsOff(t *testing.T) {
+	result, err := NewArticleExtractor().ExtractArticleWithOptions(multiSectionPage, "https://example.com/news/transit", DefaultExtractionOptions())
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+	if result.Diagnostics != nil {
+		t.Errorf("diagnostics = %+v, want none by default", result.Diagnostics)
+	}
+}
+
+func TestSkipSanitization(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "<p>Officials said", `<p data-paragraph-id="p2" style="color: red">Officials said`, 1)
+
+	tests := []struct {
+		name          string
+		skip          bool
+		wantAttribute bool
+	}{
+		{"sanitized by default", false, false},
+		{"attributes kept when skipped", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.PreserveHTML = true
+			options.SkipSanitization = tt.skip
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			if !strings.Contains(result.Content, "<p") || !strings.Contains(result.Content, "first bus lanes") {
+				t.Fatalf("content = %q, want the article HTML", result.Content)
+			}
+			if got := strings.Contains(result.Content, `data-paragraph-id="p2"`); got != tt.wantAttribute {
+				t.Errorf("data attribute kept = %v, want %v: %q", got, tt.wantAttribute, result.Content)
+			}
+		})
+	}
+}
+
+func TestIncludeQuotes(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "</article>", "<blockquote><p>This is the biggest change to our streets in a generation.</p></blockquote></article>", 1)
+
+	tests := []struct {
+		name    string
+		include bool
+		want    []string
+	}{
+		{"off", false, nil},
+		{"quote collected", true, []string{"This is the biggest change to our streets in a generation."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeQuotes = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if !equalStrings(result.Quotes, tt.want) {
+				t.Errorf("quotes = %q, want %q", result.Quotes, tt.want)
+			}
+			if !strings.Contains(result.Content, "biggest change to our streets") {
+				t.Errorf("quote missing from content: %q", result.Content)
+			}
+		})
+	}
+}
+
+func TestPreserveLineBreaks(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "</article>", "<p>The woods are lovely, dark and deep,<br>But I have promises to keep,<br>And miles to go before I sleep.</p></article>", 1)
+
+	tests := []struct {
+		name     string
+		preserve bool
+		want     string
+	}{
+		{"collapsed by default", false, "The woods are lovely, dark and deep,But I have promises to keep,And miles to go before I sleep."},
+		{"lines preserved", true, "The woods are lovely, dark and deep,\nBut I have promises to keep,\nAnd miles to go before I sleep."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.PreserveLineBreaks = tt.preserve
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if !strings.Contains(result.Content, tt.want) {
+				t.Errorf("content misses %q: %q", tt.want, result.Content)
+			}
+		})
+	}
+}
+
+func TestChunkSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		chunkSize  int
+		wantChunks int
+	}{
+		{"off", 0, 0},
+		{"two paragraphs per chunk", 250, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.ChunkSize = tt.chunkSize
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(multiSectionPage, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if len(result.Chunks) != tt.wantChunks {
+				t.Fatalf("chunks = %q, want %d", result.Chunks, tt.wantChunks)
+			}
+			if tt.wantChunks > 0 && strings.Join(result.Chunks, "\n") != result.Content {
+				t.Errorf("chunks %q don't rebuild content %q", result.Chunks, result.Content)
+			}
+		})
+	}
+}
+
+func TestMaxContentLengthChunks(t *testing.T) {
+	options := DefaultExtractionOptions()
+	options.MaxContentLength = 200
+	options.ChunkSize = 120
+	options.IncludeParagraphs = true
+	result, err := NewArticleExtractor().ExtractArticleWithOptions(multiSectionPage, "https://example.com/news/transit", options)
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+
+	if !result.Truncated || len([]rune(result.Content)) > options.MaxContentLength {
+		t.Fatalf("content = %q (truncated %v), want at most %d characters", result.Content, result.Truncated, options.MaxContentLength)
+	}
+	if strings.Join(result.Chunks, "\n") != result.Content {
+		t.Errorf("chunks %q don't rebuild the truncated content %q", result.Chunks, result.Content)
+	}
+	if result.ContentHash != HashContent(result.Content) {
+		t.Errorf("content hash not computed from the truncated content")
+	}
+	if len(strings.Join(result.Paragraphs, "\n")) <= len(result.Content) {
+		t.Errorf("paragraphs %q cut with the content, want the full article", result.Paragraphs)
+	}
+}
+
+func TestMarkHeadings(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "<p>Residents who spoke", "<h2>Reaction</h2><h3>Downtown</h3><p>Residents who spoke", 1)
+
+	tests := []struct {
+		name           string
+		mark           bool
+		preserveBreaks bool
+		want           []string
+		wantAbsent     []string
+	}{
+		{"unmarked by default", false, false, nil, []string{"## Reaction", "Reaction\n"}},
+		{"headings marked by level", true, false, []string{"## Reaction\n", "### Downtown\n"}, nil},
+		{"marked with line breaks preserved", true, true, []string{"## Reaction\n", "### Downtown\n"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.MarkHeadings = tt.mark
+			options.PreserveLineBreaks = tt.preserveBreaks
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(result.Content, want) {
+					t.Errorf("content misses %q: %q", want, result.Content)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(result.Content, absent) {
+					t.Errorf("content has %q: %q", absent, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestTitleCandidates(t *testing.T) {
+	const og = `<meta property="og:title" content="Transit Plan Approved | Best Local News, Breaking Updates">`
+
+	tests := []struct {
+		name      string
+		head      string
+		include   bool
+		wantTitle string
+		want      []models.TitleCandidate
+	}{
+		{
+			name:      "off",
+			head:      og,
+			wantTitle: "Transit Plan Approved | Best Local News, Breaking Updates",
+		},
+		{
+			name:      "og, h1 and title listed",
+			head:      og + `<svg><title>Search icon</title></svg>`,
+			include:   true,
+			wantTitle: "Transit Plan Approved | Best Local News, Breaking Updates",
+			want: []models.TitleCandidate{
+				{Text: "Transit Plan Approved | Best Local News, Breaking Updates", Source: TitleSourceOpenGraph},
+				{Text: "Transit Plan Approved", Source: TitleSourceHeading},
+				{Text: "Transit Plan Approved", Source: TitleSourceTitleTag},
+			},
+		},
+		{
+			name:      "h1 is the best without meta titles",
+			include:   true,
+			wantTitle: "Transit Plan Approved",
+			want: []models.TitleCandidate{
+				{Text: "Transit Plan Approved", Source: TitleSourceHeading},
+				{Text: "Transit Plan Approved", Source: TitleSourceTitleTag},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := strings.Replace(multiSectionPage, "</head>", tt.head+"</head>", 1)
+			options := DefaultExtractionOptions()
+			options.IncludeTitleCandidates = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if result.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", result.Title, tt.wantTitle)
+			}
+			if !reflect.DeepEqual(result.TitleCandidates, tt.want) {
+				t.Errorf("title candidates = %+v, want %+v", result.TitleCandidates, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishDateLocal(t *testing.T) {
+	tests := []struct {
+		name        string
+		head        string
+		wantPublish string
+		wantLocal   string
+	}{
+		{
+			name:        "json-ld with a +09:00 offset",
+			head:        `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","datePublished":"2024-03-05T08:30:00+09:00"}</script>`,
+			wantPublish: "2024-03-04T23:30:00Z",
+			wantLocal:   "2024-03-05T08:30:00+09:00",
+		},
+		{
+			name:        "meta tag with a negative offset",
+			head:        `<meta property="article:published_time" content="2024-03-05T08:30:00-05:00">`,
+			wantPublish: "2024-03-05T13:30:00Z",
+			wantLocal:   "2024-03-05T08:30:00-05:00",
+		},
+		{
+			name:        "utc source",
+			head:        `<meta property="article:published_time" content="2024-03-05T08:30:00Z">`,
+			wantPublish: "2024-03-05T08:30:00Z",
+			wantLocal:   "2024-03-05T08:30:00Z",
+		},
+		{
+			name:        "no offset given",
+			head:        `<meta property="article:published_time" content="2024-03-05">`,
+			wantPublish: "2024-03-05T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := strings.Replace(multiSectionPage, "</head>", tt.head+"</head>", 1)
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", DefaultExtractionOptions())
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if result.PublishDate != tt.wantPublish || result.PublishDateLocal != tt.wantLocal {
+				t.Errorf("published %q, local %q, want %q, %q", result.PublishDate, result.PublishDateLocal, tt.wantPublish, tt.wantLocal)
+			}
+		})
+	}
+}
+
+func TestIncludeImagePositions(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "<p>Residents who spoke", `<figure><img src="/media/bus-lane.jpg" width="1200" height="630"></figure><p>Residents who spoke`, 1)
+
+	tests := []struct {
+		name    string
+		include bool
+		want    []models.ImagePosition
+	}{
+		{"off", false, nil},
+		{"image after paragraph two", true, []models.ImagePosition{{URL: "https://example.com/media/bus-lane.jpg", ParagraphIndex: 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeImagePositions = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if !reflect.DeepEqual(result.ImagePositions, tt.want) {
+				t.Errorf("image positions = %+v, want %+v", result.ImagePositions, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludeMetaTags(t *testing.T) {
+	page := strings.Replace(multiSectionPage, "</head>", `<meta property="og:site_name" content="City Desk"><meta name="parsely-section" content="Transport"></head>`, 1)
+
+	tests := []struct {
+		name    string
+		include bool
+		want    map[string]string
+	}{
+		{"off", false, nil},
+		{"arbitrary tags mapped", true, map[string]string{"og:site_name": "City Desk", "parsely-section": "Transport"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeMetaTags = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if !reflect.DeepEqual(result.MetaTags, tt.want) {
+				t.Errorf("MetaTags = %v, want %v", result.MetaTags, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package scraper
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// customHeadersKey carries a fetch's custom headers to CheckRedirect
+type customHeadersKey struct{}
+
+// CleanRequestHeaders canonicalizes a request's custom header names, dropping invalid
+// names and values, BlockedRequestHeaders (except Host when allowHost is set) and, in
+// name order, any past MaxCustomHeaders
+func CleanRequestHeaders(headers map[string]string, allowHost bool) map[string]string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cleaned := make(map[string]string)
+	for _, name := range names {
+		value := strings.TrimSpace(headers[name])
+		if !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) || len(value) > MaxCustomHeaderBytes {
+			continue
+		}
+
+		name = http.CanonicalHeaderKey(name)
+		if blockedRequestHeader(name) && !(allowHost && name == "Host") {
+			continue
+		}
+		if len(cleaned) >= MaxCustomHeaders {
+			break
+		}
+		cleaned[name] = value
+	}
+
+	if len(cleaned) == 0 {
+		return nil
+	}
+	return cleaned
+}
+
+// blockedRequestHeader reports whether a canonical header name is in BlockedRequestHeaders
+func blockedRequestHeader(name string) bool {
+	for _, blocked := range BlockedRequestHeaders {
+		if name == blocked {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSite reports whether two URLs are on the same site, ignoring the www, mobile and
+// AMP subdomains its alternates are served from
+func sameSite(a, b *url.URL) bool {
+	return siteHost(strings.ToLower(a.Hostname())) == siteHost(strings.ToLower(b.Hostname()))
+}
+
+// setCustomHeaders applies cleaned custom headers over the defaults. net/http ignores
+// Host in Header, so an allowed Host override goes to req.Host.
+func (h *HTTPClient) setCustomHeaders(req *http.Request, headers map[string]string) {
+	for name, value := range headers {
+		if name == "Host" {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+}
+
+// scopeCustomHeaders is called from CheckRedirect to keep custom headers to the site
+// they were sent for: a redirect leaving it gets the default headers back instead
+func (h *HTTPClient) scopeCustomHeaders(req *http.Request, via []*http.Request) {
+	headers, ok := req.Context().Value(customHeadersKey{}).(map[string]string)
+	if !ok || sameSite(req.URL, via[0].URL) {
+		return
+	}
+
+	defaults := &http.Request{Header: http.Header{}}
+	h.setRequestHeaders(defaults)
+	for name := range headers {
+		if value := defaults.Header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		} else {
+			req.Header.Del(name)
+		}
+	}
+}
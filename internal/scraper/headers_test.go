@@ -0,0 +1,159 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"extract-html-scraper/internal/config"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestCleanRequestHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		allowHost bool
+		want      map[string]string
+	}{
+		{"none", nil, false, nil},
+		{"canonicalized and trimmed", map[string]string{"x-api-token": " abc "}, false, map[string]string{"X-Api-Token": "abc"}},
+		{"hop-by-hop dropped", map[string]string{"Connection": "close", "transfer-encoding": "chunked", "Cookie": "a=1"}, false, map[string]string{"Cookie": "a=1"}},
+		{"host blocked by default", map[string]string{"Host": "internal.example.com"}, false, nil},
+		{"host allowed", map[string]string{"Host": "internal.example.com"}, true, map[string]string{"Host": "internal.example.com"}},
+		{"invalid name and value dropped", map[string]string{"Bad Name": "x", "X-Injected": "a\r\nb", "X-Ok": "1"}, false, map[string]string{"X-Ok": "1"}},
+		{"oversized value dropped", map[string]string{"X-Big": strings.Repeat("a", MaxCustomHeaderBytes+1)}, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanRequestHeaders(tt.headers, tt.allowHost); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CleanRequestHeaders = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanRequestHeadersLimit(t *testing.T) {
+	headers := make(map[string]string)
+	for i := 0; i < MaxCustomHeaders+5; i++ {
+		headers[fmt.Sprintf("X-Custom-%02d", i)] = "1"
+	}
+	if got := CleanRequestHeaders(headers, false); len(got) != MaxCustomHeaders {
+		t.Errorf("kept %d headers, want %d", len(got), MaxCustomHeaders)
+	}
+}
+
+func TestSameSite(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://example.com/a", "https://example.com/b", true},
+		{"https://www.example.com/a", "https://m.example.com/b", true},
+		{"https://EXAMPLE.com/a", "https://amp.example.com/b", true},
+		{"https://example.com/a", "https://cdn.example.net/b", false},
+		{"https://news.example.com/a", "https://example.com/b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, _ := url.Parse(tt.a)
+			b, _ := url.Parse(tt.b)
+			if got := sameSite(a, b); got != tt.want {
+				t.Errorf("sameSite = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchCustomHeaders(t *testing.T) {
+	// The other site is the same server reached as localhost instead of 127.0.0.1
+	var seen []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Clone())
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/story", http.StatusFound)
+		case "/offsite":
+			http.Redirect(w, r, strings.Replace("http://"+r.Host, "127.0.0.1", "localhost", 1)+"/story", http.StatusFound)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, articleHTML("Headers"))
+		}
+	}))
+	defer server.Close()
+
+	headers := map[string]string{"X-Api-Token": "secret", "User-Agent": "custom-agent", "Connection": "close"}
+
+	tests := []struct {
+		name         string
+		path         string
+		wantFinal    string // X-Api-Token on the final request
+		wantAgent    string
+		wantRequests int
+	}{
+		{"custom header sent", "/story", "secret", "custom-agent", 1},
+		{"kept on a same-site redirect", "/moved", "secret", "custom-agent", 2},
+		{"dropped on a cross-site redirect", "/offsite", "", config.DefaultScrapeConfig().UserAgent, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			if _, err := NewHTTPClient().Fetch(context.Background(), server.URL+tt.path, FetchOptions{Headers: headers}, 0); err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if len(seen) != tt.wantRequests {
+				t.Fatalf("requests = %d, want %d", len(seen), tt.wantRequests)
+			}
+			if seen[0].Get("X-Api-Token") != "secret" {
+				t.Errorf("first request X-Api-Token = %q", seen[0].Get("X-Api-Token"))
+			}
+			final := seen[len(seen)-1]
+			if final.Get("X-Api-Token") != tt.wantFinal || final.Get("User-Agent") != tt.wantAgent {
+				t.Errorf("final request X-Api-Token %q, User-Agent %q, want %q, %q", final.Get("X-Api-Token"), final.Get("User-Agent"), tt.wantFinal, tt.wantAgent)
+			}
+		})
+	}
+}
+
+func TestMergeHeaderEntries(t *testing.T) {
+	requestHeaders := network.Headers{"user-agent": "Chrome", "Accept": "text/html"}
+	custom := map[string]string{"User-Agent": "custom-agent", "X-Api-Token": "secret", "Host": "internal"}
+
+	var got []string
+	for _, entry := range mergeHeaderEntries(requestHeaders, custom) {
+		got = append(got, entry.Name+": "+entry.Value)
+	}
+	sort.Strings(got)
+
+	want := []string{"Accept: text/html", "User-Agent: custom-agent", "X-Api-Token: secret"}
+	if !equalStrings(got, want) {
+		t.Errorf("merged headers = %q, want %q", got, want)
+	}
+}
+
+func TestSitePatterns(t *testing.T) {
+	var got []string
+	for _, pattern := range sitePatterns("https://www.example.com/news/story") {
+		got = append(got, pattern.URLPattern)
+	}
+
+	for _, want := range []string{"*://example.com/*", "*://www.example.com/*", "*://m.example.com:*/*", "*://amp.example.com/*"} {
+		found := false
+		for _, pattern := range got {
+			found = found || pattern == want
+		}
+		if !found {
+			t.Errorf("patterns %q miss %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// parseDoc parses an HTML fixture, failing the test on error
+func parseDoc(t testing.TB, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	return doc
+}
+
+// equalStrings reports whether two string slices hold the same values in order
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newTestScraper returns a scraper without rate limiting or whole-scrape retries, for
+// tests against local servers
+func newTestScraper() *Scraper {
+	return &Scraper{
+		httpClient:    NewHTTPClient(),
+		browserClient: NewBrowserClient(),
+		extractor:     NewArticleExtractor(),
+		maxAttempts:   1,
+		httpsMode:     HTTPSModeAllow,
+	}
+}
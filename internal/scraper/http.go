@@ -2,17 +2,25 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
+	"unicode"
 
 	"extract-html-scraper/internal/config"
+	"extract-html-scraper/internal/models"
 
+	"golang.org/x/net/html/charset"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -28,13 +36,20 @@ func NewHTTPClient() *HTTPClient {
 
 	// Configure HTTP client with connection pooling
 	transport := &http.Transport{
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 10,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
 		IdleConnTimeout:     90 * time.Second,
 		DisableKeepAlives:   false,
 	}
+	if cfg.DNSCacheTTL > 0 {
+		transport.DialContext = NewDNSCache(cfg.DNSCacheTTL).DialContext
+	}
 
-	client := &http.Client{
+	h := &HTTPClient{
+		config:  cfg,
+		regexes: regexes,
+	}
+	h.client = &http.Client{
 		Transport: transport,
 		Timeout:   time.Duration(cfg.TimeoutMs) * time.Millisecond,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
@@ -42,31 +57,148 @@ func NewHTTPClient() *HTTPClient {
 			if len(via) >= MaxRedirects {
 				return fmt.Errorf("too many redirects")
 			}
+			recordRedirect(req)
+			h.scopeCustomHeaders(req, via)
 			return nil
 		},
 	}
 
-	return &HTTPClient{
-		client:  client,
-		config:  cfg,
-		regexes: regexes,
-	}
+	return h
 }
 
 // setRequestHeaders sets browser-like headers on the request
 func (h *HTTPClient) setRequestHeaders(req *http.Request) {
 	req.Header.Set("User-Agent", h.config.UserAgent)
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Accept-Language", DefaultAcceptLanguage)
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Upgrade-Insecure-Requests", "1")
-	req.Header.Set("Referer", "https://www.google.com/")
+	req.Header.Set("Referer", DefaultReferer)
+}
+
+// ErrNotModified is returned when a conditional request gets HTTP 304 Not Modified
+var ErrNotModified = errors.New("not modified")
+
+// ErrUnsupportedContentType is returned for responses outside the allowed content types,
+// such as images, PDFs and other binaries
+var ErrUnsupportedContentType = errors.New("unsupported content-type")
+
+// AcceptsContentType reports whether a Content-Type header is in the allowed set
+func (h *HTTPClient) AcceptsContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range h.config.AllowedContentTypes {
+		if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidAcceptLanguage reports whether a value is safe to send as Accept-Language:
+// language tags, q-values and separators only
+func ValidAcceptLanguage(value string) bool {
+	if value == "" || len(value) > 256 {
+		return false
+	}
+	for _, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("-_,;=.* ", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ValidReferer reports whether a value is safe to send as Referer: empty, for none, or
+// an absolute http(s) URL without control characters or a fragment
+func ValidReferer(value string) bool {
+	if value == "" {
+		return true
+	}
+	if len(value) > 2048 || strings.ContainsFunc(value, unicode.IsControl) {
+		return false
+	}
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" && parsed.Fragment == ""
+}
+
+// FetchOptions carries per-request inputs for HTTP fetches
+type FetchOptions struct {
+	IfNoneMatch     string // ETag seen on a previous fetch
+	IfModifiedSince string // Last-Modified seen on a previous fetch
+	AcceptPDF       bool   // Return application/pdf bodies in FetchResult.Body
+	AcceptLanguage  string // Overrides DefaultAcceptLanguage
+	TraceRedirects  bool   // Record the redirect chain in FetchResult.Redirects
+	MaxBodyBytes    int    // Overrides the configured body size limit, capped at MaxBodyBytesLimit
+	NoAlternates    bool   // Fail instead of trying AMP/mobile alternates
+
+	// Referer overrides DefaultReferer when set; an empty value sends no Referer
+	Referer *string
+
+	// Headers are set over all others, see CleanRequestHeaders, and only sent to the
+	// target's site
+	Headers map[string]string
+}
+
+// bodyLimit returns the body size limit for a fetch
+func (o FetchOptions) bodyLimit(configured int) int {
+	if o.MaxBodyBytes <= 0 {
+		return configured
+	}
+	return min(o.MaxBodyBytes, MaxBodyBytesLimit)
+}
+
+// unconditional returns the options without cache validators, for fetching other URLs
+func (o FetchOptions) unconditional() FetchOptions {
+	o.IfNoneMatch = ""
+	o.IfModifiedSince = ""
+	return o
+}
+
+// scopedTo returns the options for fetching targetURL on behalf of a request for
+// originURL: the caller's custom headers and Referer are dropped when the target is
+// on another site, so they only reach the site they were set for
+func (o FetchOptions) scopedTo(originURL, targetURL string) FetchOptions {
+	origin, err := url.Parse(originURL)
+	if err == nil {
+		if target, err := url.Parse(targetURL); err == nil && sameSite(origin, target) {
+			return o
+		}
+	}
+	o.Headers = nil
+	o.Referer = nil
+	return o
+}
+
+// FetchResult describes a completed HTTP fetch
+type FetchResult struct {
+	HTML       string
+	URL        string // Final URL after redirects
+	StatusCode int
+	Header     http.Header
+	Body       []byte // Raw body of non-HTML documents such as PDFs
+	Truncated  bool   // The body was cut at the size limit
+	Charset    string // Encoding HTML was decoded from, see decodeHTML
+
+	Redirects []models.RedirectHop // Redirect chain ending with the final response, see TraceRedirects
+}
+
+// IsPDF reports whether the fetched document is a PDF
+func (r *FetchResult) IsPDF() bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == PDFContentType
 }
 
 // retryWithBackoff implements exponential backoff for retries
-func (h *HTTPClient) retryWithBackoff(ctx context.Context, targetURL string, retryCount int) (string, error) {
+func (h *HTTPClient) retryWithBackoff(ctx context.Context, targetURL string, opts FetchOptions, retryCount int) (*FetchResult, error) {
 	if retryCount >= h.config.MaxRetries {
-		return "", fmt.Errorf("max retries exceeded")
+		return nil, fmt.Errorf("max retries exceeded")
 	}
 
 	delay := time.Duration(1000*(1<<retryCount)) * time.Millisecond
@@ -74,56 +206,392 @@ func (h *HTTPClient) retryWithBackoff(ctx context.Context, targetURL string, ret
 		delay = 5 * time.Second
 	}
 
-	time.Sleep(delay)
-	return h.FetchHTML(ctx, targetURL, retryCount+1)
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return h.Fetch(ctx, targetURL, opts, retryCount+1)
 }
 
 // FetchHTML fetches HTML content from a URL with retry logic
 func (h *HTTPClient) FetchHTML(ctx context.Context, targetURL string, retryCount int) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
+	result, err := h.Fetch(ctx, targetURL, FetchOptions{}, retryCount)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", err
+	}
+	return result.HTML, nil
+}
+
+// Fetch fetches HTML content from a URL with retry logic and conditional request support.
+// A 304 response returns the result along with ErrNotModified.
+func (h *HTTPClient) Fetch(ctx context.Context, targetURL string, opts FetchOptions, retryCount int) (*FetchResult, error) {
+	requestCtx := ctx
+	var trace *redirectTrace
+	if opts.TraceRedirects {
+		requestCtx, trace = withRedirectTrace(ctx)
+	}
+	custom := CleanRequestHeaders(opts.Headers, h.config.AllowHostHeader)
+	if custom != nil {
+		requestCtx = context.WithValue(requestCtx, customHeadersKey{}, custom)
+	}
+
+	req, err := http.NewRequestWithContext(requestCtx, "GET", targetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers to mimic a real browser
 	h.setRequestHeaders(req)
 
+	if opts.AcceptLanguage != "" {
+		req.Header.Set("Accept-Language", opts.AcceptLanguage)
+	}
+	if opts.Referer != nil {
+		if *opts.Referer == "" {
+			req.Header.Del("Referer")
+		} else {
+			req.Header.Set("Referer", *opts.Referer)
+		}
+	}
+
+	// Cache validators from a previous fetch
+	if opts.IfNoneMatch != "" {
+		req.Header.Set("If-None-Match", opts.IfNoneMatch)
+	}
+	if opts.IfModifiedSince != "" {
+		req.Header.Set("If-Modified-Since", opts.IfModifiedSince)
+	}
+
+	// The caller's headers win over all of the above
+	h.setCustomHeaders(req, custom)
+
 	resp, err := h.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		// Connection resets and timeouts are worth another try; bad hosts are not
+		if ctx.Err() == nil && isTransientNetworkError(err) {
+			return h.retryWithBackoff(ctx, targetURL, opts, retryCount)
+		}
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	result := &FetchResult{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
+	if trace != nil {
+		result.Redirects = trace.finish(resp)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		return result, ErrNotModified
+	}
+
 	// Handle 5xx server errors with retry logic
 	if resp.StatusCode >= 500 {
-		return h.retryWithBackoff(ctx, targetURL, retryCount)
+		return h.retryWithBackoff(ctx, targetURL, opts, retryCount)
 	}
 
 	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
 	// Check content type
 	contentType := resp.Header.Get("Content-Type")
-	if !strings.Contains(strings.ToLower(contentType), "text/html") {
-		return "", fmt.Errorf("non-HTML content-type: %s", contentType)
+	isPDF := opts.AcceptPDF && result.IsPDF()
+	if !isPDF && !h.AcceptsContentType(contentType) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
 	}
 
-	// Read response body with size limit
-	reader := io.LimitReader(resp.Body, int64(h.config.SizeLimitBytes))
-	body, err := io.ReadAll(reader)
+	// Read response body with size limit; one byte more tells a cut body from one that fits
+	limit := opts.bodyLimit(h.config.SizeLimitBytes)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
+	if len(body) > limit {
+		body = body[:limit]
+		result.Truncated = true
+	}
+
+	if isPDF {
+		result.Body = body
+		return result, nil
+	}
+
+	result.HTML, result.Charset = decodeHTML(body, contentType)
+	return result, nil
+}
+
+// decodeHTML converts an HTML body to UTF-8, returning it with the name of the encoding
+// it was in. The encoding comes from a byte order mark, the Content-Type charset, a
+// <meta> charset declaration, or, failing those, whether the body is valid UTF-8.
+func decodeHTML(body []byte, contentType string) (string, string) {
+	encoding, name, _ := charset.DetermineEncoding(body, contentType)
+	if name == "utf-8" {
+		return string(body), name
+	}
+
+	// A body the decoder rejects keeps its detected name, with invalid bytes replaced
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return strings.ToValidUTF8(string(body), "\uFFFD"), name
+	}
+	return string(decoded), name
+}
 
+// FetchSitemap fetches a sitemap's raw body, which may still be gzipped when served
+// as a .gz file rather than with Content-Encoding, see ParseSitemap
+func (h *HTTPClient) FetchSitemap(ctx context.Context, sitemapURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", sitemapURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	h.setRequestHeaders(req)
+	req.Header.Set("Accept", "application/xml,text/xml,application/gzip,*/*;q=0.8")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(h.config.SizeLimitBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return body, nil
+}
+
+// FetchText fetches a non-HTML text resource such as robots.txt, without retries or alternates
+func (h *HTTPClient) FetchText(ctx context.Context, targetURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	h.setRequestHeaders(req)
+	req.Header.Set("Accept", "text/plain,*/*;q=0.8")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(h.config.SizeLimitBytes)))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
 	return string(body), nil
 }
 
+// ProbeResult describes a lightweight reachability check
+type ProbeResult struct {
+	URL         string // Final URL after redirects
+	StatusCode  int
+	ContentType string
+	Blocked     bool
+}
+
+// Probe issues a single GET without retries, reading at most ProbeBodyBytes,
+// to check reachability and spot bot walls without a full fetch
+func (h *HTTPClient) Probe(ctx context.Context, targetURL string) (*ProbeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	h.setRequestHeaders(req)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, ProbeBodyBytes))
+
+	return &ProbeResult{
+		URL:         resp.Request.URL.String(),
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Blocked:     resp.Header.Get("cf-mitigated") != "" || h.LooksLikeCFBlock(string(body)),
+	}, nil
+}
+
+// ImageReachable reports whether an image URL answers 2xx with an image content type.
+// It asks with HEAD, falling back to a one-byte ranged GET for servers rejecting HEAD.
+func (h *HTTPClient) ImageReachable(ctx context.Context, imageURL string) bool {
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, imageURL, nil)
+		if err != nil {
+			return false
+		}
+		h.setRequestHeaders(req)
+		req.Header.Set("Accept", "image/avif,image/webp,image/*,*/*;q=0.8")
+		if method == http.MethodGet {
+			req.Header.Set("Range", "bytes=0-0")
+		}
+
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+			continue
+		}
+		mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		return resp.StatusCode >= 200 && resp.StatusCode < 300 && strings.HasPrefix(mediaType, "image/")
+	}
+	return false
+}
+
+// ProbeImageSize fetches the start of an image, without retries, and reads its pixel
+// size from the header; servers honoring the Range header send only that much
+func (h *HTTPClient) ProbeImageSize(ctx context.Context, imageURL string) (int, int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	h.setRequestHeaders(req)
+	req.Header.Set("Accept", "image/avif,image/webp,image/*,*/*;q=0.8")
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", ImageProbeBytes-1))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return 0, 0, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, ImageProbeBytes))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	width, height, ok := ImageSize(data)
+	if !ok {
+		return 0, 0, errors.New("unrecognized image header")
+	}
+	return width, height, nil
+}
+
+// isTransientNetworkError reports whether a request error is likely to go away on retry:
+// timeouts, resets, dropped connections and temporary DNS failures
+func isTransientNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 // LooksLikeCFBlock checks if HTML content indicates Cloudflare blocking
 func (h *HTTPClient) LooksLikeCFBlock(html string) bool {
 	return IsCloudflareBlock(fmt.Errorf(html))
 }
 
+// LooksLikeSoft404 checks if an alternate URL served its site's "page not found" page with HTTP 200
+func (h *HTTPClient) LooksLikeSoft404(html string) bool {
+	return LooksLikeSoft404(html, h.regexes["soft404"])
+}
+
+// DetectClientRedirect finds a meta refresh or simple JS location redirect in fetched HTML
+// and returns its absolute target
+func (h *HTTPClient) DetectClientRedirect(html, pageURL string) (string, bool) {
+	target := ""
+
+	// <meta http-equiv="refresh" content="0;url=...">
+	for _, tag := range h.regexes["metaTag"].FindAllString(html, -1) {
+		if !h.regexes["refreshEquiv"].MatchString(tag) {
+			continue
+		}
+		content := h.regexes["contentAttr"].FindStringSubmatch(tag)
+		if len(content) < 3 {
+			continue
+		}
+		value := content[1] + content[2]
+
+		refresh := h.regexes["refreshURL"].FindStringSubmatch(value)
+		if len(refresh) < 3 {
+			continue
+		}
+		if refresh[1] != "" {
+			if delay, err := strconv.ParseFloat(refresh[1], 64); err != nil || delay > MaxMetaRefreshDelay {
+				continue
+			}
+		}
+		target = refresh[2]
+		break
+	}
+
+	// window.location = "..." on tiny landing pages
+	if target == "" && len(html) <= MaxJSRedirectPageBytes {
+		if matches := h.regexes["jsRedirect"].FindStringSubmatch(html); len(matches) > 2 {
+			target = matches[1] + matches[2]
+		}
+	}
+
+	if target == "" {
+		return "", false
+	}
+
+	absURL, err := ResolveURL(target, pageURL)
+	if err != nil {
+		return "", false
+	}
+
+	parsed, err := url.Parse(absURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || absURL == pageURL {
+		return "", false
+	}
+
+	return absURL, true
+}
+
+// followClientRedirects follows meta refresh/JS redirects of a fetch of targetURL up to
+// MaxClientRedirects, keeping the last good page if a hop fails. Hops leaving the
+// target's site don't get the caller's headers.
+func (h *HTTPClient) followClientRedirects(ctx context.Context, targetURL string, result *FetchResult, opts FetchOptions) *FetchResult {
+	for i := 0; i < MaxClientRedirects; i++ {
+		target, ok := h.DetectClientRedirect(result.HTML, result.URL)
+		if !ok {
+			break
+		}
+
+		redirected, err := h.Fetch(ctx, target, opts.unconditional().scopedTo(targetURL, target), 0)
+		if err != nil || h.LooksLikeCFBlock(redirected.HTML) {
+			break
+		}
+		if opts.TraceRedirects {
+			redirected.Redirects = append(result.Redirects, redirected.Redirects...)
+		}
+		result = redirected
+	}
+
+	return result
+}
+
 // GenerateAlternateURLs creates alternative URLs for AMP/mobile fallback
 func (h *HTTPClient) GenerateAlternateURLs(originalURL string) ([]string, error) {
 	u, err := url.Parse(originalURL)
@@ -174,9 +642,10 @@ func (h *HTTPClient) GenerateAlternateURLs(originalURL string) ([]string, error)
 // FetchWithAlternates tries the primary URL first, then alternates in parallel
 func (h *HTTPClient) FetchWithAlternates(ctx context.Context, targetURL string) (string, string, error) {
 	// Try primary URL first
-	html, err := h.FetchHTML(ctx, targetURL, 0)
-	if err == nil && !h.LooksLikeCFBlock(html) {
-		return html, targetURL, nil
+	primary, err := h.Fetch(ctx, targetURL, FetchOptions{}, 0)
+	if err == nil && !h.LooksLikeCFBlock(primary.HTML) {
+		primary = h.followClientRedirects(ctx, targetURL, primary, FetchOptions{})
+		return primary.HTML, primary.URL, nil
 	}
 
 	// Check if we should try alternates (only for specific errors)
@@ -206,7 +675,7 @@ func (h *HTTPClient) FetchWithAlternates(ctx context.Context, targetURL string)
 		go func(url string) {
 			defer wg.Done()
 			html, err := h.FetchHTML(ctx, url, 0)
-			if err == nil && !h.LooksLikeCFBlock(html) {
+			if err == nil && !h.LooksLikeCFBlock(html) && !h.LooksLikeSoft404(html) {
 				resultChan <- struct {
 					html string
 					url  string
@@ -240,10 +709,25 @@ func (h *HTTPClient) FetchWithAlternates(ctx context.Context, targetURL string)
 
 // FetchWithAlternatesGroup uses errgroup for better error handling
 func (h *HTTPClient) FetchWithAlternatesGroup(ctx context.Context, targetURL string) (string, string, error) {
+	result, err := h.FetchWithAlternatesOptions(ctx, targetURL, FetchOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	return result.HTML, result.URL, nil
+}
+
+// FetchWithAlternatesOptions tries the primary URL first, then alternates in parallel,
+// returning the first unblocked result
+func (h *HTTPClient) FetchWithAlternatesOptions(ctx context.Context, targetURL string, opts FetchOptions) (*FetchResult, error) {
 	// Try primary URL first
-	html, err := h.FetchHTML(ctx, targetURL, 0)
-	if err == nil && !h.LooksLikeCFBlock(html) {
-		return html, targetURL, nil
+	primary, err := h.Fetch(ctx, targetURL, opts, 0)
+	if err == nil && !h.LooksLikeCFBlock(primary.HTML) {
+		return h.followClientRedirects(ctx, targetURL, primary, opts), nil
+	}
+
+	// Unchanged content needs no alternates
+	if errors.Is(err, ErrNotModified) {
+		return primary, err
 	}
 
 	// Check if we should try alternates
@@ -251,37 +735,42 @@ func (h *HTTPClient) FetchWithAlternatesGroup(ctx context.Context, targetURL str
 		!strings.Contains(err.Error(), "HTTP 406") &&
 		!strings.Contains(err.Error(), "HTTP 451") &&
 		!strings.Contains(err.Error(), "HTTP 5") {
-		return "", "", err
+		return nil, err
+	}
+
+	// Alternates may serve a different article; some callers want only the URL they asked for
+	if opts.NoAlternates || h.config.DisableAlternates {
+		if err == nil {
+			err = fmt.Errorf("blocked by Cloudflare")
+		}
+		return nil, err
 	}
 
 	// Generate alternate URLs
 	alternates, err := h.GenerateAlternateURLs(targetURL)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	// Use errgroup for parallel execution
-	g, ctx := errgroup.WithContext(ctx)
-	resultChan := make(chan struct {
-		html string
-		url  string
-	}, 1)
+	// Use errgroup for parallel execution; the group context is only
+	// cancelled once a winner is found, so one failure doesn't stop the others
+	g, groupCtx := errgroup.WithContext(ctx)
+	groupCtx, cancel := context.WithCancel(groupCtx)
+	defer cancel()
+	resultChan := make(chan *FetchResult, 1)
 
 	for _, altURL := range alternates {
 		altURL := altURL // capture loop variable
 		g.Go(func() error {
-			html, err := h.FetchHTML(ctx, altURL, 0)
-			if err == nil && !h.LooksLikeCFBlock(html) {
+			result, err := h.Fetch(groupCtx, altURL, opts.unconditional(), 0)
+			if err == nil && !h.LooksLikeCFBlock(result.HTML) && !h.LooksLikeSoft404(result.HTML) {
 				select {
-				case resultChan <- struct {
-					html string
-					url  string
-				}{html, altURL}:
-				case <-ctx.Done():
+				case resultChan <- result:
+					cancel()
+				default:
 				}
-				return nil
 			}
-			return err
+			return nil
 		})
 	}
 
@@ -292,9 +781,12 @@ func (h *HTTPClient) FetchWithAlternatesGroup(ctx context.Context, targetURL str
 	}()
 
 	select {
-	case result := <-resultChan:
-		return result.html, result.url, nil
+	case result, ok := <-resultChan:
+		if !ok {
+			return nil, fmt.Errorf("all alternate URLs failed or were blocked")
+		}
+		return result, nil
 	case <-ctx.Done():
-		return "", "", ctx.Err()
+		return nil, ctx.Err()
 	}
 }
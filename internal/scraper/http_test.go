@@ -0,0 +1,550 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"extract-html-scraper/internal/models"
+)
+
+// articleHTML is a small page that extracts as a real article
+func articleHTML(title string) string {
+	return fmt.Sprintf(`<html><head><title>%s</title></head><body><article><h1>%s</h1>
+<p>The council approved the new transit plan on Tuesday after months of public hearings and debate.</p>
+<p>Officials said the first bus lanes will open next spring, with more routes following in the autumn.</p>
+<p>Residents who spoke at the meeting were broadly supportive, though some raised concerns about parking.</p>
+</article></body></html>`, title, title)
+}
+
+func TestDetectClientRedirect(t *testing.T) {
+	tests := []struct {
+		name   string
+		html   string
+		want   string
+		wantOK bool
+	}{
+		{"meta refresh", `<meta http-equiv="refresh" content="0;url=/article">`, "https://example.com/article", true},
+		{"meta refresh quoted url", `<meta http-equiv="Refresh" content="1; URL='https://other.example.com/a'">`, "https://other.example.com/a", true},
+		{"slow refresh is a reload", `<meta http-equiv="refresh" content="30;url=/article">`, "", false},
+		{"js location", `<script>window.location.href = "/landing/real"</script>`, "https://example.com/landing/real", true},
+		{"js replace", `<script>location.replace('https://example.com/b')</script>`, "https://example.com/b", true},
+		{"self redirect", `<meta http-equiv="refresh" content="0;url=https://example.com/start">`, "", false},
+		{"non-web scheme", `<meta http-equiv="refresh" content="0;url=javascript:alert(1)">`, "", false},
+		{"none", `<p>Hello</p>`, "", false},
+	}
+
+	h := NewHTTPClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := h.DetectClientRedirect("<html><head>"+tt.html+"</head></html>", "https://example.com/start")
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("DetectClientRedirect() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetchFollowsMetaRefresh(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/landing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><meta http-equiv="refresh" content="0;url=/article"></head><body>Redirecting</body></html>`)
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, articleHTML("Real Article"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	result, err := NewHTTPClient().FetchWithAlternatesOptions(context.Background(), server.URL+"/landing", FetchOptions{})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if result.URL != server.URL+"/article" {
+		t.Errorf("URL = %q, want the refresh target", result.URL)
+	}
+
+	extracted, err := NewArticleExtractor().ExtractArticleWithOptions(result.HTML, result.URL, DefaultExtractionOptions())
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+	if extracted.Title != "Real Article" {
+		t.Errorf("Title = %q, want the real article's", extracted.Title)
+	}
+}
+
+// conditionalServer serves articleHTML with an ETag and Last-Modified, answering 304
+// to requests carrying either validator
+func conditionalServer() *httptest.Server {
+	const etag, lastModified = `"v1"`, "Mon, 02 Jan 2006 15:04:05 GMT"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		if r.Header.Get("If-None-Match") == etag || r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, articleHTML("Cached Article"))
+	}))
+}
+
+func TestFetchConditional(t *testing.T) {
+	server := conditionalServer()
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		opts            FetchOptions
+		wantNotModified bool
+	}{
+		{"unconditional", FetchOptions{}, false},
+		{"matching etag", FetchOptions{IfNoneMatch: `"v1"`}, true},
+		{"stale etag", FetchOptions{IfNoneMatch: `"v0"`}, false},
+		{"matching last-modified", FetchOptions{IfModifiedSince: "Mon, 02 Jan 2006 15:04:05 GMT"}, true},
+	}
+
+	h := NewHTTPClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := h.FetchWithAlternatesOptions(context.Background(), server.URL, tt.opts)
+			if got := errors.Is(err, ErrNotModified); got != tt.wantNotModified {
+				t.Fatalf("not modified = %v (err %v), want %v", got, err, tt.wantNotModified)
+			}
+			if result.Header.Get("ETag") != `"v1"` {
+				t.Errorf("ETag = %q", result.Header.Get("ETag"))
+			}
+			if !tt.wantNotModified && result.HTML == "" {
+				t.Error("empty body on a full fetch")
+			}
+		})
+	}
+}
+
+func TestIsTransientNetworkError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection reset", &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"dropped connection", fmt.Errorf("Get: %w", io.EOF), true},
+		{"unexpected eof", io.ErrUnexpectedEOF, true},
+		{"timeout", &net.DNSError{Err: "i/o timeout", IsTimeout: true}, true},
+		{"temporary dns", &net.DNSError{Err: "server misbehaving", IsTemporary: true}, true},
+		{"no such host", &net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{"connection refused", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, false},
+		{"other", errors.New("tls: bad certificate"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientNetworkError(tt.err); got != tt.want {
+				t.Errorf("isTransientNetworkError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchRetriesDroppedConnection(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, articleHTML("Second Try"))
+	}))
+	defer server.Close()
+
+	result, err := NewHTTPClient().Fetch(context.Background(), server.URL, FetchOptions{}, 0)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if attempts.Load() != 2 || !strings.Contains(result.HTML, "Second Try") {
+		t.Errorf("attempts = %d, html %q", attempts.Load(), result.HTML)
+	}
+}
+
+func TestFetchDoesNotRetryRefusedConnection(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	start := time.Now()
+	if _, err := NewHTTPClient().Fetch(context.Background(), server.URL, FetchOptions{}, 0); err == nil {
+		t.Fatal("fetch of a closed server succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("refused connection took %v, want no retry backoff", elapsed)
+	}
+}
+
+func TestFetchContentTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		wantErr     error
+	}{
+		{"html", "text/html; charset=utf-8", nil},
+		{"xhtml", "application/xhtml+xml", nil},
+		{"image", "image/png", ErrUnsupportedContentType},
+		{"json", "application/json", ErrUnsupportedContentType},
+		{"missing", "", ErrUnsupportedContentType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header()["Content-Type"] = []string{tt.contentType}
+				fmt.Fprint(w, articleHTML("Typed Page"))
+			}))
+			defer server.Close()
+
+			result, err := NewHTTPClient().Fetch(context.Background(), server.URL, FetchOptions{}, 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("fetch error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !strings.Contains(result.HTML, "Typed Page") {
+				t.Errorf("html = %q", result.HTML)
+			}
+		})
+	}
+}
+
+func TestFetchAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{"default", "", DefaultAcceptLanguage},
+		{"requested languages", "fr-FR,fr;q=0.9", "fr-FR,fr;q=0.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Accept-Language")
+				w.Header().Set("Content-Type", "text/html")
+				fmt.Fprint(w, articleHTML("Localized"))
+			}))
+			defer server.Close()
+
+			if _, err := NewHTTPClient().Fetch(context.Background(), server.URL, FetchOptions{AcceptLanguage: tt.acceptLanguage}, 0); err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Accept-Language = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"de-DE,de;q=0.9,en;q=0.5", true},
+		{"*", true},
+		{"", false},
+		{"en\r\nX-Injected: 1", false},
+		{strings.Repeat("en,", 100), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := ValidAcceptLanguage(tt.value); got != tt.want {
+				t.Errorf("ValidAcceptLanguage(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchTraceRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/old", http.RedirectHandler("/moved", http.StatusMovedPermanently))
+	mux.Handle("/moved", http.RedirectHandler("/story", http.StatusFound))
+	mux.HandleFunc("/story", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, articleHTML("Final Stop"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		trace bool
+		want  []models.RedirectHop
+	}{
+		{"not traced", false, nil},
+		{"two-hop chain", true, []models.RedirectHop{
+			{URL: server.URL + "/old", StatusCode: http.StatusMovedPermanently},
+			{URL: server.URL + "/moved", StatusCode: http.StatusFound},
+			{URL: server.URL + "/story", StatusCode: http.StatusOK},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewHTTPClient().Fetch(context.Background(), server.URL+"/old", FetchOptions{TraceRedirects: tt.trace}, 0)
+			if err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if result.URL != server.URL+"/story" {
+				t.Errorf("final URL = %q", result.URL)
+			}
+
+			if len(result.Redirects) != len(tt.want) {
+				t.Fatalf("redirects = %+v, want %d hops", result.Redirects, len(tt.want))
+			}
+			for i, hop := range result.Redirects {
+				if hop.URL != tt.want[i].URL || hop.StatusCode != tt.want[i].StatusCode || hop.DurationMs < 0 {
+					t.Errorf("hop %d = %+v, want %+v", i, hop, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFetchBodyLimit(t *testing.T) {
+	page := articleHTML("Long Read")
+
+	tests := []struct {
+		name          string
+		maxBodyBytes  int
+		wantLen       int
+		wantTruncated bool
+	}{
+		{"configured limit", 0, len(page), false},
+		{"override smaller than the body", 100, 100, true},
+		{"override exactly the body", len(page), len(page), false},
+		{"override larger than the body", len(page) * 2, len(page), false},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewHTTPClient().Fetch(context.Background(), server.URL, FetchOptions{MaxBodyBytes: tt.maxBodyBytes}, 0)
+			if err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if len(result.HTML) != tt.wantLen || result.Truncated != tt.wantTruncated {
+				t.Errorf("body %d bytes, truncated %v, want %d, %v", len(result.HTML), result.Truncated, tt.wantLen, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxBodyBytes int
+		want         int
+	}{
+		{"unset keeps the configured limit", 0, 6_000_000},
+		{"negative keeps the configured limit", -1, 6_000_000},
+		{"smaller override", 50_000, 50_000},
+		{"override capped", MaxBodyBytesLimit + 1, MaxBodyBytesLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (FetchOptions{MaxBodyBytes: tt.maxBodyBytes}).bodyLimit(6_000_000); got != tt.want {
+				t.Errorf("bodyLimit = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageReachable(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    bool
+	}{
+		{"image", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/jpeg")
+		}, true},
+		{"not found", func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		}, false},
+		{"html error page", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+		}, false},
+		{"auth required", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.WriteHeader(http.StatusForbidden)
+		}, false},
+		{"head rejected, ranged get", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			if r.Header.Get("Range") != "bytes=0-0" {
+				t.Errorf("Range = %q", r.Header.Get("Range"))
+			}
+			w.Header().Set("Content-Type", "image/webp")
+			w.WriteHeader(http.StatusPartialContent)
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			if got := NewHTTPClient().ImageReachable(context.Background(), server.URL+"/hero.jpg"); got != tt.want {
+				t.Errorf("ImageReachable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisableAlternates(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            string
+		noAlternates   bool
+		wantAlternates bool
+	}{
+		{"alternates tried", "", false, true},
+		{"disabled per request", "", true, false},
+		{"disabled by environment", "true", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SCRAPE_DISABLE_ALTERNATES", tt.env)
+
+			var alternates atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/story" && r.URL.RawQuery == "" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				alternates.Add(1)
+				w.Header().Set("Content-Type", "text/html")
+				fmt.Fprint(w, articleHTML("Alternate"))
+			}))
+			defer server.Close()
+
+			_, err := NewHTTPClient().FetchWithAlternatesOptions(context.Background(), server.URL+"/story", FetchOptions{NoAlternates: tt.noAlternates})
+			if got := alternates.Load() > 0; got != tt.wantAlternates {
+				t.Errorf("alternate requests = %d, want any %v", alternates.Load(), tt.wantAlternates)
+			}
+			if tt.wantAlternates && err != nil {
+				t.Errorf("fetch: %v", err)
+			}
+			if !tt.wantAlternates && (err == nil || !strings.Contains(err.Error(), "HTTP 403")) {
+				t.Errorf("fetch error = %v, want the primary's 403", err)
+			}
+		})
+	}
+}
+
+func TestDecodeHTML(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        []byte
+		contentType string
+		want        string
+		wantCharset string
+	}{
+		{"utf-8", []byte("<p>Café</p>"), "text/html; charset=utf-8", "<p>Café</p>", "utf-8"},
+		{"latin1 header", []byte("<p>Caf\xe9</p>"), "text/html; charset=iso-8859-1", "<p>Café</p>", "windows-1252"},
+		{"meta charset", []byte("<meta charset=\"windows-1252\"><p>Caf\xe9</p>"), "text/html", `<meta charset="windows-1252"><p>Café</p>`, "windows-1252"},
+		{"undeclared utf-8", []byte("<p>Café</p>"), "text/html", "<p>Café</p>", "utf-8"},
+		// The mark itself is left for StripInvisibleChars
+		{"utf-16 byte order mark", []byte("\xff\xfe<\x00p\x00>\x00"), "text/html", "\ufeff<p>", "utf-16le"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, charset := decodeHTML(tt.body, tt.contentType)
+			if got != tt.want || charset != tt.wantCharset {
+				t.Errorf("decodeHTML = %q, %q, want %q, %q", got, charset, tt.want, tt.wantCharset)
+			}
+		})
+	}
+}
+
+func TestFetchReferer(t *testing.T) {
+	custom, none := "https://www.facebook.com/", ""
+
+	tests := []struct {
+		name    string
+		referer *string
+		want    string
+	}{
+		{"default", nil, DefaultReferer},
+		{"configured referer", &custom, custom},
+		{"no referer", &none, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Values("Referer")
+				w.Header().Set("Content-Type", "text/html")
+				fmt.Fprint(w, articleHTML("Referred"))
+			}))
+			defer server.Close()
+
+			if _, err := NewHTTPClient().Fetch(context.Background(), server.URL, FetchOptions{Referer: tt.referer}, 0); err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("Referer = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidReferer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"empty for none", "", true},
+		{"https", "https://www.google.com/", true},
+		{"http with query", "http://news.example.com/front?ref=1", true},
+		{"other scheme", "ftp://example.com/", false},
+		{"relative", "/relative/path", false},
+		{"fragment", "https://example.com/#top", false},
+		{"header injection", "https://example.com/\r\nX-Injected: 1", false},
+		{"too long", "https://example.com/" + strings.Repeat("a", 2048), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidReferer(tt.value); got != tt.want {
+				t.Errorf("ValidReferer(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
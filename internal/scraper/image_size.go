@@ -0,0 +1,53 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ImageSize reads an image's pixel size from the start of its file, which is all
+// GIF, JPEG, PNG and WebP headers need. ok is false for other formats and for
+// headers cut short.
+func ImageSize(data []byte) (width, height int, ok bool) {
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		return cfg.Width, cfg.Height, cfg.Width > 0 && cfg.Height > 0
+	}
+	return webpSize(data)
+}
+
+// webpSize parses the lossy (VP8), lossless (VP8L) and extended (VP8X) WebP headers
+func webpSize(data []byte) (width, height int, ok bool) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, false
+	}
+
+	chunk := data[20:]
+	switch string(data[12:16]) {
+	case "VP8 ":
+		// Frame tag, then the 0x9d012a start code, then 14-bit dimensions
+		if chunk[3] != 0x9d || chunk[4] != 0x01 || chunk[5] != 0x2a {
+			return 0, 0, false
+		}
+		width = int(binary.LittleEndian.Uint16(chunk[6:8]) & 0x3fff)
+		height = int(binary.LittleEndian.Uint16(chunk[8:10]) & 0x3fff)
+	case "VP8L":
+		// Signature byte, then 14-bit width-1 and height-1 packed in 28 bits
+		if chunk[0] != 0x2f {
+			return 0, 0, false
+		}
+		bits := binary.LittleEndian.Uint32(chunk[1:5])
+		width = int(bits&0x3fff) + 1
+		height = int(bits>>14&0x3fff) + 1
+	case "VP8X":
+		// Flags and reserved bytes, then 24-bit canvas width-1 and height-1
+		width = (int(chunk[4]) | int(chunk[5])<<8 | int(chunk[6])<<16) + 1
+		height = (int(chunk[7]) | int(chunk[8])<<8 | int(chunk[9])<<16) + 1
+	default:
+		return 0, 0, false
+	}
+	return width, height, width > 0 && height > 0
+}
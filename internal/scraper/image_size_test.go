@@ -0,0 +1,57 @@
+package scraper
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// encodedImage encodes a blank image of the given size with encode
+func encodedImage(t testing.TB, width, height int, encode func(*bytes.Buffer, image.Image) error) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// webpHeader builds the first bytes of a WebP file with the given chunk
+func webpHeader(fourCC string, chunk ...byte) []byte {
+	data := append([]byte("RIFF\x00\x00\x00\x00WEBP"+fourCC+"\x00\x00\x00\x00"), chunk...)
+	return append(data, make([]byte, 16)...)
+}
+
+func TestImageSize(t *testing.T) {
+	pngData := encodedImage(t, 1200, 630, func(b *bytes.Buffer, m image.Image) error { return png.Encode(b, m) })
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantWidth  int
+		wantHeight int
+		wantOK     bool
+	}{
+		{"png", pngData, 1200, 630, true},
+		{"jpeg", encodedImage(t, 800, 450, func(b *bytes.Buffer, m image.Image) error { return jpeg.Encode(b, m, nil) }), 800, 450, true},
+		{"gif", encodedImage(t, 320, 240, func(b *bytes.Buffer, m image.Image) error { return gif.Encode(b, m, nil) }), 320, 240, true},
+		// 14-bit little-endian 640 and 480 after the frame tag and start code
+		{"webp lossy", webpHeader("VP8 ", 0, 0, 0, 0x9d, 0x01, 0x2a, 0x80, 0x02, 0xe0, 0x01), 640, 480, true},
+		// 24-bit width-1 and height-1 after four flag bytes
+		{"webp extended", webpHeader("VP8X", 0, 0, 0, 0, 0x7f, 0x07, 0x00, 0x37, 0x04, 0x00), 1920, 1080, true},
+		{"truncated png", pngData[:12], 0, 0, false},
+		{"not an image", []byte("<html>not found</html>"), 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height, ok := ImageSize(tt.data)
+			if width != tt.wantWidth || height != tt.wantHeight || ok != tt.wantOK {
+				t.Errorf("ImageSize = %d, %d, %v, want %d, %d, %v", width, height, ok, tt.wantWidth, tt.wantHeight, tt.wantOK)
+			}
+		})
+	}
+}
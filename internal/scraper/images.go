@@ -3,7 +3,9 @@ package scraper
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,28 +19,59 @@ import (
 type ImageExtractor struct {
 	config  config.ImageConfig
 	regexes map[string]*regexp.Regexp
+	options ExtractionOptions
 }
 
 func NewImageExtractor() *ImageExtractor {
-	cfg := config.DefaultImageConfig()
+	return NewImageExtractorWithOptions(DefaultExtractionOptions())
+}
+
+// NewImageExtractorWithOptions creates an image extractor honoring per-request extraction options
+func NewImageExtractorWithOptions(options ExtractionOptions) *ImageExtractor {
+	cfg := options.imageConfig(config.DefaultImageConfig())
 	regexes := config.CompileRegexes()
+	regexes["imageExt"] = config.ImageExtRegex(cfg.Extensions)
 
 	return &ImageExtractor{
 		config:  cfg,
 		regexes: regexes,
+		options: options,
 	}
 }
 
 // ExtractImagesFromHTML extracts and scores images from HTML content
 func (ie *ImageExtractor) ExtractImagesFromHTML(html, baseURL string) []string {
-	// Parse HTML once with goquery
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
 		return []string{}
 	}
 
+	return ie.ExtractImagesFromDocument(doc, baseURL)
+}
+
+// ExtractImagesFromDocument extracts and scores images from an already-parsed document.
+// The document is only read, so callers can keep using it afterwards.
+func (ie *ImageExtractor) ExtractImagesFromDocument(doc *goquery.Document, baseURL string) []string {
+	_, images := ie.ExtractImagesWithMain(doc, baseURL)
+	return images
+}
+
+// ExtractImagesWithMain returns the hero image along with the top images. The hero is the
+// og:image when it passes the filters and PreferOGMainImage is set, else the best-scoring image.
+func (ie *ImageExtractor) ExtractImagesWithMain(doc *goquery.Document, baseURL string) (string, []string) {
+	mainImage, images := ie.extractImagesWithMain(doc, baseURL, nil)
+	return mainImage.URL, images
+}
+
+// extractImagesWithMain is ExtractImagesWithMain, returning the hero's candidate so its
+// size is known, and recording candidate counts and rejection reasons in diagnostics
+// when it is non-nil
+func (ie *ImageExtractor) extractImagesWithMain(doc *goquery.Document, baseURL string, diagnostics *models.Diagnostics) (models.ImageCandidate, []string) {
+	// Relative URLs resolve against <base href> when the page declares one
+	baseURL = ResolveBaseURL(doc, baseURL)
+
 	// Extract candidates concurrently
-	candidatesChan := make(chan []models.ImageCandidate, 2)
+	candidatesChan := make(chan []models.ImageCandidate, 3)
 	var wg sync.WaitGroup
 
 	// Extract og:image concurrently
@@ -53,6 +86,13 @@ func (ie *ImageExtractor) ExtractImagesFromHTML(html, baseURL string) []string {
 		}
 	}()
 
+	// Extract preloaded hero images concurrently
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		candidatesChan <- ie.extractPreloadImages(doc, baseURL)
+	}()
+
 	// Extract img tags concurrently
 	wg.Add(1)
 	go func() {
@@ -74,18 +114,72 @@ func (ie *ImageExtractor) ExtractImagesFromHTML(html, baseURL string) []string {
 	}
 
 	// Filter and score candidates
-	filtered := ie.filterAndScoreCandidates(allCandidates)
+	var rejections map[string]int
+	if diagnostics != nil {
+		rejections = make(map[string]int)
+	}
+	filtered := ie.filterAndScoreCandidates(allCandidates, rejections)
+	if diagnostics != nil {
+		diagnostics.ImageCandidates = len(allCandidates)
+		diagnostics.ImagesKept = len(filtered)
+		diagnostics.ImageRejections = rejections
+	}
+	mainImage := ie.pickMainImage(filtered)
 
-	// Sort by score and area
-	ie.sortCandidates(filtered)
+	// Order by score and area, or by position in the article
+	if ie.options.ImageOrder == ImageOrderDocument {
+		filtered = ie.documentOrder(filtered)
+	} else {
+		ie.sortCandidates(filtered)
+	}
 
-	// Return top images
-	return ie.getTopImages(filtered, DefaultImageLimit)
+	// Return top images, with spares to replace unreachable ones when they get validated
+	limit := DefaultImageLimit
+	if ie.options.ValidateImages {
+		limit = ImageValidationCandidates
+	}
+	return mainImage, ie.getTopImages(filtered, limit)
+}
+
+// pickMainImage selects the hero image from the filtered candidates, with its output URL
+func (ie *ImageExtractor) pickMainImage(candidates []models.ImageCandidate) models.ImageCandidate {
+	if len(candidates) == 0 {
+		return models.ImageCandidate{}
+	}
+
+	main := candidates[0]
+	picked := false
+	if ie.options.PreferOGMainImage {
+		for _, c := range candidates {
+			if c.Source == "og" {
+				main, picked = c, true
+				break
+			}
+		}
+	}
+	if !picked {
+		ranked := append([]models.ImageCandidate(nil), candidates...)
+		ie.sortCandidates(ranked)
+		main = ranked[0]
+	}
+
+	main.URL = ie.outputURL(main.URL)
+	return main
+}
+
+// PreviewImage returns the og:image for link previews. Size filters don't apply,
+// since the page picked it as its share image, but denied hosts do.
+func (ie *ImageExtractor) PreviewImage(doc *goquery.Document, baseURL string) string {
+	ogImage := ie.extractOgImage(doc, ResolveBaseURL(doc, baseURL))
+	if ogImage == nil || !ie.passesHostFilter(*ogImage) {
+		return ""
+	}
+	return ie.outputURL(ogImage.URL)
 }
 
 // extractOgImage extracts Open Graph image metadata
 func (ie *ImageExtractor) extractOgImage(doc *goquery.Document, baseURL string) *models.ImageCandidate {
-	var ogImageURL string
+	var ogImageURL, ogImageType string
 	var width, height int
 
 	// Find og:image meta tag
@@ -100,6 +194,8 @@ func (ie *ImageExtractor) extractOgImage(doc *goquery.Document, baseURL string)
 			if content, exists := s.Attr("content"); exists {
 				ogImageURL = content
 			}
+		case "og:image:type":
+			ogImageType = s.AttrOr("content", "")
 		case "og:image:width":
 			if content, exists := s.Attr("content"); exists {
 				if w, err := strconv.Atoi(content); err == nil {
@@ -126,7 +222,7 @@ func (ie *ImageExtractor) extractOgImage(doc *goquery.Document, baseURL string)
 	}
 
 	// Check if it's an image file
-	if !ie.regexes["imageExt"].MatchString(absURL) {
+	if !ie.isImageURL(absURL, ogImageType) {
 		return nil
 	}
 
@@ -148,16 +244,55 @@ func (ie *ImageExtractor) extractOgImage(doc *goquery.Document, baseURL string)
 		InArticle: true, // og:image is considered in-article
 		BadHint:   false,
 		Source:    "og",
+		Index:     -1,
 	}
 }
 
-// extractImgTags extracts all img tags from the document
+// extractPreloadImages extracts <link rel="preload" as="image"> hints, which some sites
+// use as their only hero image signal. The srcset variant is picked like an <img>'s,
+// since imagesizes only matters for layout.
+func (ie *ImageExtractor) extractPreloadImages(doc *goquery.Document, baseURL string) []models.ImageCandidate {
+	var candidates []models.ImageCandidate
+
+	doc.Find(`link[rel~="preload"][as="image"]`).Each(func(i int, s *goquery.Selection) {
+		href := strings.TrimSpace(s.AttrOr("href", ""))
+		if srcset := s.AttrOr("imagesrcset", ""); srcset != "" {
+			if picked := ie.pickFromSrcset(srcset); picked != "" {
+				href = picked
+			}
+		}
+		if href == "" {
+			return
+		}
+
+		absURL, err := ie.toAbsoluteURL(href, baseURL)
+		if err != nil || !ie.isImageURL(absURL, s.AttrOr("type", "")) {
+			return
+		}
+
+		width, height := ie.parseDimensionsFromURL(absURL)
+		candidates = append(candidates, models.ImageCandidate{
+			URL:       absURL,
+			Width:     width,
+			Height:    height,
+			InArticle: true, // preloaded as the page's key image
+			BadHint:   ie.regexes["badHint"].MatchString(absURL),
+			Source:    "preload",
+			Index:     -1,
+		})
+	})
+
+	return candidates
+}
+
+// extractImgTags extracts all img tags from the document, including AMP <amp-img>
 func (ie *ImageExtractor) extractImgTags(doc *goquery.Document, baseURL string) []models.ImageCandidate {
 	var candidates []models.ImageCandidate
 
-	doc.Find("img").Each(func(i int, s *goquery.Selection) {
+	doc.Find(ImageTags).Each(func(i int, s *goquery.Selection) {
 		candidate := ie.extractImgTag(s, baseURL)
 		if candidate != nil {
+			candidate.Index = i
 			candidates = append(candidates, *candidate)
 		}
 	})
@@ -167,25 +302,7 @@ func (ie *ImageExtractor) extractImgTags(doc *goquery.Document, baseURL string)
 
 // extractImgTag extracts a single img tag
 func (ie *ImageExtractor) extractImgTag(s *goquery.Selection, baseURL string) *models.ImageCandidate {
-	// Get src attribute or data-src variants
-	src := ""
-	if srcAttr, exists := s.Attr("src"); exists {
-		src = srcAttr
-	} else if dataSrc, exists := s.Attr("data-src"); exists {
-		src = dataSrc
-	} else if dataOriginal, exists := s.Attr("data-original"); exists {
-		src = dataOriginal
-	} else if dataLazySrc, exists := s.Attr("data-lazy-src"); exists {
-		src = dataLazySrc
-	}
-
-	// Try srcset if no src found
-	if src == "" {
-		if srcset, exists := s.Attr("srcset"); exists {
-			src = ie.pickFromSrcset(srcset)
-		}
-	}
-
+	src := ie.imageSource(s)
 	if src == "" {
 		return nil
 	}
@@ -197,7 +314,7 @@ func (ie *ImageExtractor) extractImgTag(s *goquery.Selection, baseURL string) *m
 	}
 
 	// Check if it's an image file
-	if !ie.regexes["imageExt"].MatchString(absURL) {
+	if !ie.isImageURL(absURL, pictureSourceType(s)) {
 		return nil
 	}
 
@@ -231,6 +348,73 @@ func (ie *ImageExtractor) extractImgTag(s *goquery.Selection, baseURL string) *m
 	}
 }
 
+// InlineImageURL returns the absolute URL of an in-content image for inline rendering.
+// Size filters don't apply since the image stays where the author placed it.
+func (ie *ImageExtractor) InlineImageURL(s *goquery.Selection, baseURL string) string {
+	src := ie.imageSource(s)
+	if src == "" {
+		return ""
+	}
+
+	absURL, err := ie.toAbsoluteURL(src, baseURL)
+	if err != nil || !strings.HasPrefix(absURL, "http") {
+		return ""
+	}
+	return ie.outputURL(absURL)
+}
+
+// imageSource reads the src attribute, its lazy-loading variants, or the best srcset entry
+func (ie *ImageExtractor) imageSource(s *goquery.Selection) string {
+	// Get src attribute or data-src variants
+	src := ""
+	if srcAttr, exists := s.Attr("src"); exists {
+		src = srcAttr
+	} else if dataSrc, exists := s.Attr("data-src"); exists {
+		src = dataSrc
+	} else if dataOriginal, exists := s.Attr("data-original"); exists {
+		src = dataOriginal
+	} else if dataLazySrc, exists := s.Attr("data-lazy-src"); exists {
+		src = dataLazySrc
+	}
+
+	// Try srcset if no src found
+	if src == "" {
+		if srcset, exists := s.Attr("srcset"); exists {
+			src = ie.pickFromSrcset(srcset)
+		}
+	}
+
+	return src
+}
+
+// isImageURL accepts URLs with an allowed image extension, or extensionless URLs
+// with a declared image MIME type when the option is on
+func (ie *ImageExtractor) isImageURL(absURL, declaredType string) bool {
+	if ie.regexes["imageExt"].MatchString(absURL) {
+		return true
+	}
+
+	if !ie.options.AllowExtensionlessImages || !strings.HasPrefix(strings.ToLower(strings.TrimSpace(declaredType)), "image/") {
+		return false
+	}
+
+	parsed, err := url.Parse(absURL)
+	if err != nil {
+		return false
+	}
+	return !strings.Contains(path.Base(parsed.Path), ".")
+}
+
+// pictureSourceType returns the image MIME type declared by a <source> in the img's <picture>
+func pictureSourceType(s *goquery.Selection) string {
+	declared := ""
+	s.Parent().Filter("picture").Find("source[type]").EachWithBreak(func(i int, source *goquery.Selection) bool {
+		declared = source.AttrOr("type", "")
+		return !strings.HasPrefix(strings.ToLower(declared), "image/")
+	})
+	return declared
+}
+
 // extractDimensions extracts width and height from img tag
 func (ie *ImageExtractor) extractDimensions(s *goquery.Selection) (int, int) {
 	width := 0
@@ -304,43 +488,47 @@ func (ie *ImageExtractor) parseDimensionsFromURL(url string) (int, int) {
 	return width, height
 }
 
-// pickFromSrcset selects the best image from srcset
+// pickFromSrcset selects the best image from srcset: the width closest to
+// TargetImageWidth, or without widths the highest density. Inline data: URIs are
+// placeholders, never picked.
 func (ie *ImageExtractor) pickFromSrcset(srcset string) string {
-	items := strings.Split(srcset, ",")
-	var candidates []struct {
-		url string
-		w   int
-	}
-
-	for _, item := range items {
-		item = strings.TrimSpace(item)
-		matches := ie.regexes["srcsetItem"].FindStringSubmatch(item)
-		if len(matches) > 2 {
-			if w, err := strconv.Atoi(matches[2]); err == nil {
-				candidates = append(candidates, struct {
-					url string
-					w   int
-				}{matches[1], w})
-			}
+	var widths, densities []SrcsetCandidate
+	for _, candidate := range ParseSrcset(srcset) {
+		if strings.HasPrefix(strings.ToLower(candidate.URL), "data:") {
+			continue
+		}
+		if candidate.Width > 0 {
+			widths = append(widths, candidate)
+		} else {
+			densities = append(densities, candidate)
 		}
 	}
 
-	if len(candidates) == 0 {
-		return ""
+	if len(widths) == 0 {
+		if len(densities) == 0 {
+			return ""
+		}
+		best := densities[0]
+		for _, candidate := range densities[1:] {
+			if candidate.Density > best.Density {
+				best = candidate
+			}
+		}
+		return best.URL
 	}
 
 	// Find closest to TargetImageWidth, preferring larger images
-	best := candidates[0]
-	for _, candidate := range candidates[1:] {
-		candidateDiff := absInt(candidate.w - TargetImageWidth)
-		bestDiff := absInt(best.w - TargetImageWidth)
+	best := widths[0]
+	for _, candidate := range widths[1:] {
+		candidateDiff := absInt(candidate.Width - TargetImageWidth)
+		bestDiff := absInt(best.Width - TargetImageWidth)
 		if candidateDiff < bestDiff ||
-			(candidateDiff == bestDiff && candidate.w > best.w) {
+			(candidateDiff == bestDiff && candidate.Width > best.Width) {
 			best = candidate
 		}
 	}
 
-	return best.url
+	return best.URL
 }
 
 // isInArticleScope checks if the img tag is within article or main tags
@@ -361,12 +549,16 @@ func (ie *ImageExtractor) hasBadHint(s *goquery.Selection, url string) bool {
 	return ie.regexes["badHint"].MatchString(html)
 }
 
-// filterAndScoreCandidates filters and scores image candidates
-func (ie *ImageExtractor) filterAndScoreCandidates(candidates []models.ImageCandidate) []models.ImageCandidate {
+// filterAndScoreCandidates filters and scores image candidates, tallying the rejection
+// reasons in rejections when it is non-nil
+func (ie *ImageExtractor) filterAndScoreCandidates(candidates []models.ImageCandidate, rejections map[string]int) []models.ImageCandidate {
 	var filtered []models.ImageCandidate
 
 	for _, c := range candidates {
-		if !ie.passesFilters(c) {
+		if reason := ie.rejectionReason(c); reason != "" {
+			if rejections != nil {
+				rejections[reason]++
+			}
 			continue
 		}
 
@@ -379,39 +571,67 @@ func (ie *ImageExtractor) filterAndScoreCandidates(candidates []models.ImageCand
 	return filtered
 }
 
-// passesFilters checks if a candidate passes all filters
-func (ie *ImageExtractor) passesFilters(c models.ImageCandidate) bool {
-	if c.Width > 0 && c.Height > 0 {
-		shortSide := min(c.Width, c.Height)
-		area := c.Width * c.Height
-
-		// Size filters
-		if shortSide < ie.config.MinShortSide {
-			return false
-		}
-		if area < ie.config.MinArea {
-			return false
-		}
+// rejectionReason returns the first filter enabled in the options that rejects a
+// candidate, or "" when it passes them all
+func (ie *ImageExtractor) rejectionReason(c models.ImageCandidate) string {
+	if !ie.passesHostFilter(c) {
+		return RejectImageHost
+	}
+	if ie.options.FilterImageSize && !ie.passesSizeFilter(c) {
+		return RejectImageSize
+	}
+	if ie.options.FilterImageAspect && !ie.passesAspectFilter(c) {
+		return RejectImageAspect
+	}
+	if ie.options.FilterAdSizes && !ie.passesAdSizeFilter(c) {
+		return RejectImageAdSize
+	}
+	if ie.options.FilterBadHints && !ie.passesBadHintFilter(c) {
+		return RejectImageBadHint
+	}
+	return ""
+}
 
-		// Aspect ratio filter
-		if !ie.hasGoodAspectRatio(c.Width, c.Height) {
-			return false
-		}
+// passesHostFilter rejects images served from a denied host; it can't be turned off per request
+func (ie *ImageExtractor) passesHostFilter(c models.ImageCandidate) bool {
+	if len(ie.config.DeniedHosts) == 0 {
+		return true
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return true
+	}
+	return !hasHostSuffix(strings.ToLower(u.Hostname()), ie.config.DeniedHosts)
+}
 
-		// Ad size filter
-		if ie.isAdSize(c.Width, c.Height) {
-			return false
-		}
+// passesSizeFilter rejects images below the minimum short side or area; unknown sizes pass
+func (ie *ImageExtractor) passesSizeFilter(c models.ImageCandidate) bool {
+	if c.Width <= 0 || c.Height <= 0 {
+		return true
+	}
+	return min(c.Width, c.Height) >= ie.config.MinShortSide && c.Width*c.Height >= ie.config.MinArea
+}
 
-		// Bad hint filter with exceptions
-		if c.BadHint && !(shortSide >= 400 && area >= 300000) {
-			return false
-		}
-	} else if c.BadHint {
-		return false
+// passesAspectFilter rejects banner- and strip-shaped images; unknown sizes pass
+func (ie *ImageExtractor) passesAspectFilter(c models.ImageCandidate) bool {
+	if c.Width <= 0 || c.Height <= 0 {
+		return true
 	}
+	return ie.hasGoodAspectRatio(c.Width, c.Height)
+}
+
+// passesAdSizeFilter rejects images with standard ad creative dimensions
+func (ie *ImageExtractor) passesAdSizeFilter(c models.ImageCandidate) bool {
+	return !ie.isAdSize(c.Width, c.Height)
+}
 
-	return true
+// passesBadHintFilter rejects images hinting at ads, icons and the like, unless they
+// are known to be large
+func (ie *ImageExtractor) passesBadHintFilter(c models.ImageCandidate) bool {
+	if !c.BadHint {
+		return true
+	}
+	return c.Width > 0 && c.Height > 0 && min(c.Width, c.Height) >= 400 && c.Width*c.Height >= 300000
 }
 
 // hasGoodAspectRatio checks if the aspect ratio is acceptable
@@ -444,7 +664,29 @@ func (ie *ImageExtractor) isAdSize(width, height int) bool {
 	}
 
 	sizeKey := fmt.Sprintf("%dx%d", width, height)
-	return ie.config.AdSizes[sizeKey]
+	if ie.config.AdSizes[sizeKey] || ie.config.AdSizeTol <= 0 {
+		return ie.config.AdSizes[sizeKey]
+	}
+
+	// Near-standard sizes, e.g. a banner with a border
+	for adSize := range ie.config.AdSizes {
+		adWidth, adHeight, ok := parseAdSize(adSize)
+		if ok && absInt(width-adWidth) <= ie.config.AdSizeTol && absInt(height-adHeight) <= ie.config.AdSizeTol {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAdSize splits an AdSizes key like "728x90" into its width and height
+func parseAdSize(size string) (int, int, bool) {
+	w, h, found := strings.Cut(size, "x")
+	if !found {
+		return 0, 0, false
+	}
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	return width, height, errW == nil && errH == nil
 }
 
 // calculateScore calculates the score for a candidate
@@ -495,38 +737,123 @@ func (ie *ImageExtractor) sortCandidates(candidates []models.ImageCandidate) {
 	}
 }
 
-// getTopImages returns the top N unique image URLs
+// documentOrder keeps in-article candidates in the order they appear in the page
+func (ie *ImageExtractor) documentOrder(candidates []models.ImageCandidate) []models.ImageCandidate {
+	var inArticle []models.ImageCandidate
+	for _, c := range candidates {
+		if c.InArticle {
+			inArticle = append(inArticle, c)
+		}
+	}
+
+	sort.SliceStable(inArticle, func(i, j int) bool {
+		return inArticle[i].Index < inArticle[j].Index
+	})
+	return inArticle
+}
+
+// getTopImages returns the top N distinct images. Size variants of one image (resize
+// params, CDN hosts, -300x200 suffixes) count once, at the position of the first variant,
+// using the highest-scoring variant's URL.
 func (ie *ImageExtractor) getTopImages(candidates []models.ImageCandidate, limit int) []string {
-	seen := make(map[string]bool)
-	var result []string
+	best := make(map[string]models.ImageCandidate)
+	var keys []string
+
+	var pageKeys map[string]string
+	if ie.options.MergeOGImage {
+		pageKeys = ie.pageImageKeys(candidates)
+	}
 
 	for _, c := range candidates {
-		if !seen[c.URL] {
-			seen[c.URL] = true
-			result = append(result, c.URL)
-			if len(result) >= limit {
-				break
-			}
+		key := ie.imageDedupeKey(c.URL)
+		if pageKey, ok := pageKeys[imagePathKey(key)]; ok && c.Source == "og" {
+			key = pageKey
+		}
+		current, seen := best[key]
+		if !seen {
+			keys = append(keys, key)
+		}
+		if !seen || c.Score > current.Score {
+			best[key] = c
+		}
+	}
+
+	var result []string
+	for _, key := range keys {
+		result = append(result, ie.outputURL(best[key].URL))
+		if len(result) >= limit {
+			break
 		}
 	}
 
 	return result
 }
 
-// toAbsoluteURL converts a relative URL to absolute
-func (ie *ImageExtractor) toAbsoluteURL(relativeURL, baseURL string) (string, error) {
-	base, err := url.Parse(baseURL)
+// imageDedupeKey normalizes an image URL to identify size variants of the same image:
+// the path without the host, size segments or suffixes, plus non-resize query params
+func (ie *ImageExtractor) imageDedupeKey(imageURL string) string {
+	u, err := url.Parse(imageURL)
 	if err != nil {
-		return "", err
+		return imageURL
 	}
 
-	rel, err := url.Parse(relativeURL)
-	if err != nil {
-		return "", err
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" && !ie.regexes["imageSizeSegment"].MatchString(segment) {
+			segments = append(segments, segment)
+		}
+	}
+	if n := len(segments); n > 0 {
+		ext := path.Ext(segments[n-1])
+		stem := strings.TrimSuffix(segments[n-1], ext)
+		segments[n-1] = ie.regexes["imageSizeSuffix"].ReplaceAllString(stem, "") + strings.ToLower(ext)
 	}
 
-	abs := base.ResolveReference(rel)
-	return abs.String(), nil
+	query := u.Query()
+	for _, param := range ImageResizeParams {
+		query.Del(param)
+	}
+
+	key := strings.Join(segments, "/")
+	if encoded := query.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+	return key
+}
+
+// pageImageKeys maps the path part of each non-og candidate's dedupe key to the full key,
+// so an og:image differing from an in-page image only by query params can share its key
+func (ie *ImageExtractor) pageImageKeys(candidates []models.ImageCandidate) map[string]string {
+	keys := make(map[string]string)
+	for _, c := range candidates {
+		if c.Source == "og" {
+			continue
+		}
+		key := ie.imageDedupeKey(c.URL)
+		if _, seen := keys[imagePathKey(key)]; !seen {
+			keys[imagePathKey(key)] = key
+		}
+	}
+	return keys
+}
+
+// imagePathKey drops the query part of a dedupe key
+func imagePathKey(key string) string {
+	pathKey, _, _ := strings.Cut(key, "?")
+	return pathKey
+}
+
+// outputURL applies per-request normalization to an image URL before it is returned
+func (ie *ImageExtractor) outputURL(imageURL string) string {
+	if ie.options.StripTrackingParams {
+		return StripImageTrackingParams(imageURL)
+	}
+	return imageURL
+}
+
+// toAbsoluteURL converts a relative URL to absolute
+func (ie *ImageExtractor) toAbsoluteURL(relativeURL, baseURL string) (string, error) {
+	return ResolveURL(relativeURL, baseURL)
 }
 
 // Helper functions
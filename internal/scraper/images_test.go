@@ -0,0 +1,422 @@
+package scraper
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestImageOrder(t *testing.T) {
+	page := `<html><body><article>
+<img src="https://cdn.example.com/small.jpg" width="640" height="480">
+<img src="https://cdn.example.com/large.jpg" width="1600" height="1200">
+<img src="https://cdn.example.com/medium.jpg" width="1024" height="768">
+</article></body></html>`
+
+	tests := []struct {
+		name  string
+		order string
+		want  []string
+	}{
+		{"score", ImageOrderScore, []string{"https://cdn.example.com/large.jpg", "https://cdn.example.com/medium.jpg", "https://cdn.example.com/small.jpg"}},
+		{"document", ImageOrderDocument, []string{"https://cdn.example.com/small.jpg", "https://cdn.example.com/large.jpg", "https://cdn.example.com/medium.jpg"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.ImageOrder = tt.order
+			if got := NewImageExtractorWithOptions(options).ExtractImagesFromHTML(page, "https://example.com/a"); !equalStrings(got, tt.want) {
+				t.Errorf("images = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinImageDimensions(t *testing.T) {
+	page := `<html><body><article>
+<img src="https://cdn.example.com/compact.jpg" width="400" height="250">
+</article></body></html>`
+
+	tests := []struct {
+		name      string
+		shortSide int
+		area      int
+		want      int
+	}{
+		{"defaults filter it", 0, 0, 0},
+		{"lower short side alone", 200, 0, 0},
+		{"lower short side and area", 200, 50000, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.MinImageShortSide = tt.shortSide
+			options.MinImageArea = tt.area
+			if got := NewImageExtractorWithOptions(options).ExtractImagesFromHTML(page, "https://example.com/a"); len(got) != tt.want {
+				t.Errorf("images = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtensionlessImages(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       string
+		allow      bool
+		extensions []string
+		want       []string
+	}{
+		{
+			name:  "typed og:image accepted",
+			page:  `<meta property="og:image" content="https://img.example.com/p/8f3a2c"><meta property="og:image:type" content="image/jpeg"><meta property="og:image:width" content="1200"><meta property="og:image:height" content="630">`,
+			allow: true,
+			want:  []string{"https://img.example.com/p/8f3a2c"},
+		},
+		{
+			name: "typed og:image rejected by default",
+			page: `<meta property="og:image" content="https://img.example.com/p/8f3a2c"><meta property="og:image:type" content="image/jpeg"><meta property="og:image:width" content="1200"><meta property="og:image:height" content="630">`,
+		},
+		{
+			name:  "untyped og:image rejected",
+			page:  `<meta property="og:image" content="https://img.example.com/p/8f3a2c"><meta property="og:image:width" content="1200"><meta property="og:image:height" content="630">`,
+			allow: true,
+		},
+		{
+			name:  "picture source type",
+			page:  `<article><picture><source type="image/webp" srcset="https://img.example.com/p/77b1"><img src="https://img.example.com/p/77b1" width="1200" height="800"></picture></article>`,
+			allow: true,
+			want:  []string{"https://img.example.com/p/77b1"},
+		},
+		{
+			name:       "custom extensions",
+			page:       `<article><img src="https://cdn.example.com/photo.jxl" width="1200" height="800"><img src="https://cdn.example.com/photo.gif" width="1200" height="800"></article>`,
+			extensions: []string{"jxl", ".png"},
+			want:       []string{"https://cdn.example.com/photo.jxl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.AllowExtensionlessImages = tt.allow
+			options.ImageExtensions = tt.extensions
+			if got := NewImageExtractorWithOptions(options).ExtractImagesFromHTML(tt.page, "https://example.com/a"); !equalStrings(got, tt.want) {
+				t.Errorf("images = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMainImage(t *testing.T) {
+	page := `<html><head>
+<meta property="og:image" content="https://cdn.example.com/share-800x600.jpg">
+<meta property="og:image:width" content="800"><meta property="og:image:height" content="600">
+</head><body><article>
+<img src="https://cdn.example.com/photo-2000x1500.jpg" width="2000" height="1500">
+</article></body></html>`
+
+	tests := []struct {
+		name     string
+		preferOG bool
+		want     string
+	}{
+		{"og preferred though smaller", true, "https://cdn.example.com/share-800x600.jpg"},
+		{"best-scoring image otherwise", false, "https://cdn.example.com/photo-2000x1500.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.PreferOGMainImage = tt.preferOG
+			mainImage, images := NewImageExtractorWithOptions(options).ExtractImagesWithMain(parseDoc(t, page), "https://example.com/a")
+			if mainImage != tt.want {
+				t.Errorf("main image = %q, want %q", mainImage, tt.want)
+			}
+			if len(images) != 2 {
+				t.Errorf("images = %v, want both", images)
+			}
+		})
+	}
+}
+
+func TestAMPImages(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "amp-img",
+			body: `<amp-img src="/media/bridge.jpg" width="1200" height="800" layout="responsive"></amp-img>`,
+			want: []string{"https://example.com/media/bridge.jpg"},
+		},
+		{
+			name: "amp-img with noscript fallback",
+			body: `<amp-img src="/media/bridge.jpg" width="1200" height="800"><noscript><img src="/media/bridge.jpg" width="1200" height="800"></noscript></amp-img>`,
+			want: []string{"https://example.com/media/bridge.jpg"},
+		},
+		{
+			name: "small amp-img filtered",
+			body: `<amp-img src="/media/icon.png" width="48" height="48"></amp-img>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := "<html><body><article>" + tt.body + "</article></body></html>"
+			if got := NewImageExtractor().ExtractImagesFromHTML(page, "https://example.com/amp/story"); !equalStrings(got, tt.want) {
+				t.Errorf("images = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		img     string
+		disable func(*ExtractionOptions)
+	}{
+		{
+			name:    "size",
+			img:     `<img src="https://cdn.example.com/thumb.jpg" width="160" height="120">`,
+			disable: func(o *ExtractionOptions) { o.FilterImageSize = false },
+		},
+		{
+			name:    "aspect ratio",
+			img:     `<img src="https://cdn.example.com/panorama.jpg" width="3000" height="600">`,
+			disable: func(o *ExtractionOptions) { o.FilterImageAspect = false },
+		},
+		{
+			name:    "ad sizes",
+			img:     `<img src="https://cdn.example.com/banner.jpg" width="300" height="600">`,
+			disable: func(o *ExtractionOptions) { o.FilterAdSizes = false },
+		},
+		{
+			name:    "bad hints",
+			img:     `<img src="https://cdn.example.com/promo-photo.jpg" width="640" height="360">`,
+			disable: func(o *ExtractionOptions) { o.FilterBadHints = false },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := "<html><body><article>" + tt.img + "</article></body></html>"
+
+			options := DefaultExtractionOptions()
+			if got := NewImageExtractorWithOptions(options).ExtractImagesFromHTML(page, "https://example.com/a"); len(got) != 0 {
+				t.Errorf("filter on: images = %v, want none", got)
+			}
+
+			tt.disable(&options)
+			if got := NewImageExtractorWithOptions(options).ExtractImagesFromHTML(page, "https://example.com/a"); len(got) != 1 {
+				t.Errorf("filter off: images = %v, want the image", got)
+			}
+		})
+	}
+}
+
+func TestImageDedupeKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{"resize params", "https://cdn.example.com/photo.jpg?w=600", "https://cdn.example.com/photo.jpg?w=1200&h=800", true},
+		{"wordpress size suffix", "https://example.com/uploads/photo-300x200.jpg", "https://example.com/uploads/photo.jpg", true},
+		{"other cdn host", "https://cdn1.example.com/media/photo.jpg", "https://cdn2.example.com/media/photo.jpg", true},
+		{"different images", "https://cdn.example.com/photo-a.jpg", "https://cdn.example.com/photo-b.jpg", false},
+		{"non-resize query kept", "https://cdn.example.com/image.jpg?id=1", "https://cdn.example.com/image.jpg?id=2", false},
+	}
+
+	ie := NewImageExtractor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ie.imageDedupeKey(tt.a) == ie.imageDedupeKey(tt.b); got != tt.same {
+				t.Errorf("same key for %s and %s = %v, want %v", tt.a, tt.b, got, tt.same)
+			}
+		})
+	}
+}
+
+func TestSizeVariantsCollapse(t *testing.T) {
+	page := `<html><body><article>
+<img src="https://example.com/uploads/bridge-768x512.jpg" width="768" height="512">
+<img src="https://example.com/uploads/bridge-1024x683.jpg" width="1024" height="683">
+<img src="https://example.com/uploads/bridge.jpg" width="1800" height="1200">
+<img src="https://example.com/uploads/harbor.jpg" width="1200" height="800">
+</article></body></html>`
+
+	got := NewImageExtractor().ExtractImagesFromHTML(page, "https://example.com/a")
+	want := []string{"https://example.com/uploads/bridge.jpg", "https://example.com/uploads/harbor.jpg"}
+	if !equalStrings(got, want) {
+		t.Errorf("images = %v, want %v", got, want)
+	}
+}
+
+func TestPreloadImages(t *testing.T) {
+	tests := []struct {
+		name string
+		head string
+		want []string
+	}{
+		{
+			name: "preloaded hero",
+			head: `<link rel="preload" as="image" href="/media/hero-1600x900.jpg">`,
+			want: []string{"https://example.com/media/hero-1600x900.jpg"},
+		},
+		{
+			name: "srcset candidate nearest the target width",
+			head: `<link rel="preload" as="image" href="/media/hero-320x180.jpg" imagesrcset="/media/hero-320x180.jpg 320w, /media/hero-1200x675.jpg 1200w, /media/hero-2400x1350.jpg 2400w">`,
+			want: []string{"https://example.com/media/hero-1200x675.jpg"},
+		},
+		{
+			name: "fonts ignored",
+			head: `<link rel="preload" as="font" href="/fonts/serif-1600x900.woff2">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := "<html><head>" + tt.head + "</head><body><article><p>Story.</p></article></body></html>"
+			if got := NewImageExtractor().ExtractImagesFromHTML(page, "https://example.com/a"); !equalStrings(got, tt.want) {
+				t.Errorf("images = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeniedImageHosts(t *testing.T) {
+	const page = `<html><head><meta property="og:image" content="https://img.tracker.io/hero.jpg"></head><body><article>
+<img src="https://img.tracker.io/hero.jpg" width="1200" height="630">
+<img src="https://cdn.example.com/transit.jpg" width="1200" height="630">
+</article></body></html>`
+
+	tests := []struct {
+		name     string
+		env      string
+		denied   []string
+		wantMain string
+		want     []string
+	}{
+		{"none denied", "", nil, "https://img.tracker.io/hero.jpg", []string{"https://img.tracker.io/hero.jpg", "https://cdn.example.com/transit.jpg"}},
+		{"denied per request", "", []string{"tracker.io"}, "https://cdn.example.com/transit.jpg", []string{"https://cdn.example.com/transit.jpg"}},
+		{"denied by environment", "tracker.io", nil, "https://cdn.example.com/transit.jpg", []string{"https://cdn.example.com/transit.jpg"}},
+		{"other host denied", "", []string{"example.org"}, "https://img.tracker.io/hero.jpg", []string{"https://img.tracker.io/hero.jpg", "https://cdn.example.com/transit.jpg"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SCRAPE_IMAGE_DENYLIST", tt.env)
+			options := DefaultExtractionOptions()
+			options.DeniedImageHosts = tt.denied
+
+			mainImage, images := NewImageExtractorWithOptions(options).ExtractImagesWithMain(parseDoc(t, page), "https://example.com/news/transit")
+			if mainImage != tt.wantMain {
+				t.Errorf("main image = %q, want %q", mainImage, tt.wantMain)
+			}
+			if !equalStrings(images, tt.want) {
+				t.Errorf("images = %q, want %q", images, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeOGImage(t *testing.T) {
+	tests := []struct {
+		name  string
+		og    string
+		img   string
+		merge bool
+		want  int
+	}{
+		{"kept apart by default", "https://cdn.example.com/photos/transit.jpg?crop=share&v=2", "https://cdn.example.com/photos/transit.jpg?v=1", false, 2},
+		{"same photo merged", "https://cdn.example.com/photos/transit.jpg?crop=share&v=2", "https://cdn.example.com/photos/transit.jpg?v=1", true, 1},
+		{"different photos kept", "https://cdn.example.com/photos/share-card.jpg?v=2", "https://cdn.example.com/photos/transit.jpg?v=1", true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := `<html><head><meta property="og:image" content="` + tt.og + `"></head><body><article>
+<img src="` + tt.img + `" width="1200" height="630"></article></body></html>`
+			options := DefaultExtractionOptions()
+			options.MergeOGImage = tt.merge
+
+			images := NewImageExtractorWithOptions(options).ExtractImagesFromHTML(page, "https://example.com/news/transit")
+			if len(images) != tt.want {
+				t.Errorf("images = %q, want %d", images, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdSizeTolerance(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      string
+		tolerance int
+		want      int
+	}{
+		{"exact ad size", `width="728" height="90"`, 0, 0},
+		{"near size kept without tolerance", `width="729" height="91"`, 0, 1},
+		{"near size filtered with tolerance", `width="729" height="91"`, 3, 0},
+		{"near half page filtered", `width="302" height="598"`, 3, 0},
+		{"outside tolerance kept", `width="735" height="90"`, 3, 1},
+		{"tolerance capped", `width="760" height="90"`, 100, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := `<html><body><article><img src="https://cdn.example.com/photo.jpg" ` + tt.size + `></article></body></html>`
+			options := DefaultExtractionOptions()
+			// Leaderboards trip the size and aspect filters on their own
+			options.FilterImageSize = false
+			options.FilterImageAspect = false
+			options.AdSizeTolerance = tt.tolerance
+
+			if got := NewImageExtractorWithOptions(options).ExtractImagesFromHTML(page, "https://example.com/a"); len(got) != tt.want {
+				t.Errorf("images = %q, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// largeImagePage builds an article page with many paragraphs and images, the size where
+// parsing the HTML twice shows
+func largeImagePage() string {
+	var b strings.Builder
+	b.WriteString(`<html><head><meta property="og:image" content="https://cdn.example.com/hero-1200x630.jpg"></head><body><article>`)
+	for i := 0; i < 400; i++ {
+		fmt.Fprintf(&b, `<p>Paragraph %d of a long article, with enough text to look like real content.</p>`, i)
+		if i%10 == 0 {
+			fmt.Fprintf(&b, `<figure><img src="https://cdn.example.com/photo-%d.jpg" width="1200" height="800"></figure>`, i)
+		}
+	}
+	b.WriteString(`</article></body></html>`)
+	return b.String()
+}
+
+func BenchmarkExtractImagesFromHTML(b *testing.B) {
+	page := largeImagePage()
+	ie := NewImageExtractor()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ie.ExtractImagesFromHTML(page, "https://example.com/a")
+	}
+}
+
+func BenchmarkExtractImagesFromDocument(b *testing.B) {
+	doc := parseDoc(b, largeImagePage())
+	ie := NewImageExtractor()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ie.ExtractImagesFromDocument(doc, "https://example.com/a")
+	}
+}
@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// teaserLinks matches headings linking to other pages, as on homepages and section pages
+const teaserLinks = "h2 a[href], h3 a[href]"
+
+// asideContainers hold related-story and navigation lists that articles carry too, so
+// their linked headings don't make a page an index
+const asideContainers = "aside, nav, footer"
+
+// IsIndexPage reports whether the document lists article teasers rather than holding one
+// article: several <article> blocks that are all short, or several linked headings,
+// outside asides, navs and footers, with little text per heading
+func IsIndexPage(doc *goquery.Document) bool {
+	articles := doc.Find("article")
+	if articles.Length() >= IndexMinTeasers {
+		longest := 0
+		articles.Each(func(i int, s *goquery.Selection) {
+			if words := len(strings.Fields(s.Text())); words > longest {
+				longest = words
+			}
+		})
+		return longest <= IndexMaxTeaserWords
+	}
+
+	headings := 0
+	doc.Find(teaserLinks).Each(func(i int, s *goquery.Selection) {
+		if s.ParentsFiltered(asideContainers).Length() > 0 {
+			return
+		}
+		if href := strings.TrimSpace(s.AttrOr("href", "")); href != "" && !strings.HasPrefix(href, "#") {
+			headings++
+		}
+	})
+	if headings < IndexMinTeasers {
+		return false
+	}
+	return len(strings.Fields(doc.Find("body").Text()))/headings <= IndexMaxTeaserWords
+}
+
+// LargestByText returns the element of the selection with the most text
+func LargestByText(selection *goquery.Selection) *goquery.Selection {
+	largest := selection.First()
+	longest := -1
+	selection.Each(func(i int, s *goquery.Selection) {
+		if length := len(strings.TrimSpace(s.Text())); length > longest {
+			largest, longest = s, length
+		}
+	})
+	return largest
+}
+
+// FindArticleLinks returns the absolute URLs of the articles teased on an index page: each
+// <article>'s heading link (or first link), then linked headings outside any <article>
+func FindArticleLinks(doc *goquery.Document, baseURL string) []string {
+	baseURL = ResolveBaseURL(doc, baseURL)
+	seen := make(map[string]bool)
+	var links []string
+
+	add := func(s *goquery.Selection) {
+		href := strings.TrimSpace(s.AttrOr("href", ""))
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return
+		}
+
+		link, err := ResolveURL(href, baseURL)
+		if err != nil || seen[link] {
+			return
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+
+	doc.Find("article").Each(func(i int, s *goquery.Selection) {
+		link := s.Find(HeadingTags).Find("a[href]").First()
+		if link.Length() == 0 {
+			link = s.Find("a[href]").First()
+		}
+		add(link)
+	})
+
+	doc.Find(teaserLinks).Each(func(i int, s *goquery.Selection) {
+		if s.ParentsFiltered("article").Length() == 0 {
+			add(s)
+		}
+	})
+
+	return links
+}
@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// indexPage builds a section page teasing five articles, the third with the longest summary
+func indexPage() string {
+	var b strings.Builder
+	b.WriteString(`<html><head><title>Local News</title></head><body><main>`)
+	for i := 1; i <= 5; i++ {
+		summary := "A short summary of the story for the section page."
+		if i == 3 {
+			summary = "The longest teaser on the page, with a summary that runs on for a couple of sentences. It covers the budget vote and what it means for residents."
+		}
+		fmt.Fprintf(&b, `<article><h2><a href="/news/story-%d">Story %d</a></h2><p>%s</p></article>`, i, i, summary)
+	}
+	b.WriteString(`</main></body></html>`)
+	return b.String()
+}
+
+func TestIsIndexPage(t *testing.T) {
+	var related strings.Builder
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&related, `<h3><a href="/news/related-%d">Related %d</a></h3>`, i, i)
+	}
+
+	tests := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{"five teaser articles", indexPage(), true},
+		{"single article", multiSectionPage, false},
+		{"article with related headings in an aside", strings.Replace(multiSectionPage, "<aside>", "<aside>"+related.String(), 1), false},
+		{"linked headings outside articles", "<html><body>" + related.String() + "</body></html>", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsIndexPage(parseDoc(t, tt.html)); got != tt.want {
+				t.Errorf("IsIndexPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		wantLargest bool
+		wantLinks   int
+	}{
+		{"off", IndexModeOff, false, 0},
+		{"largest teaser", IndexModeLargest, true, 0},
+		{"article links", IndexModeLinks, true, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IndexMode = tt.mode
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(indexPage(), "https://example.com/news/", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			if !result.IndexPage {
+				t.Error("index page not flagged")
+			}
+			largest := strings.Contains(result.Content, "budget vote") && !strings.Contains(result.Content, "short summary")
+			if tt.wantLargest && !largest {
+				t.Errorf("content = %q, want only the largest teaser", result.Content)
+			}
+			if len(result.ArticleLinks) != tt.wantLinks {
+				t.Errorf("article links = %v, want %d", result.ArticleLinks, tt.wantLinks)
+			}
+			if tt.wantLinks > 0 && result.ArticleLinks[0] != "https://example.com/news/story-1" {
+				t.Errorf("first link = %q", result.ArticleLinks[0])
+			}
+		})
+	}
+}
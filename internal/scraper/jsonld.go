@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractJSONLD parses all valid <script type="application/ld+json"> blocks into objects,
+// flattening top-level arrays and @graph containers. Invalid blocks are skipped.
+func ExtractJSONLD(doc *goquery.Document) []map[string]interface{} {
+	var objects []map[string]interface{}
+
+	doc.Find(`script[type="application/ld+json"]`).Each(func(i int, s *goquery.Selection) {
+		raw := cleanJSONLD(s.Text())
+		if raw == "" {
+			return
+		}
+
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+			return
+		}
+
+		objects = append(objects, flattenJSONLD(parsed)...)
+	})
+
+	return objects
+}
+
+// JSONLDString returns the first non-empty string value of key across the objects
+func JSONLDString(objects []map[string]interface{}, key string) string {
+	for _, object := range objects {
+		if value, ok := object[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// flattenJSONLD expands arrays and @graph containers into a flat list of objects
+func flattenJSONLD(value interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		var objects []map[string]interface{}
+		for _, item := range v {
+			objects = append(objects, flattenJSONLD(item)...)
+		}
+		return objects
+	case map[string]interface{}:
+		if graph, ok := v["@graph"]; ok {
+			return flattenJSONLD(graph)
+		}
+		return []map[string]interface{}{v}
+	}
+	return nil
+}
+
+// cleanJSONLD strips HTML comment and CDATA wrappers some CMSes put around JSON-LD
+func cleanJSONLD(raw string) string {
+	raw = strings.TrimSpace(raw)
+	for _, wrapper := range []string{"<!--", "-->", "//<![CDATA[", "//]]>", "<![CDATA[", "]]>"} {
+		raw = strings.ReplaceAll(raw, wrapper, "")
+	}
+	return strings.TrimSpace(raw)
+}
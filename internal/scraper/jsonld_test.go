@@ -0,0 +1,76 @@
+package scraper
+
+import "testing"
+
+func TestExtractJSONLD(t *testing.T) {
+	tests := []struct {
+		name      string
+		scripts   []string
+		wantTypes []string
+	}{
+		{
+			name:      "graph with two typed objects",
+			scripts:   []string{`{"@context":"https://schema.org","@graph":[{"@type":"NewsArticle","headline":"A"},{"@type":"Organization","name":"Paper"}]}`},
+			wantTypes: []string{"NewsArticle", "Organization"},
+		},
+		{
+			name:      "top-level array",
+			scripts:   []string{`[{"@type":"WebPage"},{"@type":"BreadcrumbList"}]`},
+			wantTypes: []string{"WebPage", "BreadcrumbList"},
+		},
+		{
+			name:      "invalid block skipped",
+			scripts:   []string{`{"@type": "Article",`, `{"@type":"Person","name":"Ada"}`},
+			wantTypes: []string{"Person"},
+		},
+		{
+			name:      "comment wrapper",
+			scripts:   []string{`<!-- {"@type":"Article"} -->`},
+			wantTypes: []string{"Article"},
+		},
+		{
+			name:    "none",
+			scripts: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := "<html><head>"
+			for _, script := range tt.scripts {
+				page += `<script type="application/ld+json">` + script + `</script>`
+			}
+			objects := ExtractJSONLD(parseDoc(t, page+"</head><body></body></html>"))
+
+			var types []string
+			for _, object := range objects {
+				types = append(types, object["@type"].(string))
+			}
+			if !equalStrings(types, tt.wantTypes) {
+				t.Errorf("types = %v, want %v", types, tt.wantTypes)
+			}
+		})
+	}
+}
+
+func TestStructuredDataPassthrough(t *testing.T) {
+	page := `<html><head><script type="application/ld+json">{"@graph":[{"@type":"NewsArticle"},{"@type":"Person"}]}</script></head>
+<body><article><p>The council approved the new transit plan on Tuesday after months of debate.</p></article></body></html>`
+
+	for _, include := range []bool{false, true} {
+		options := DefaultExtractionOptions()
+		options.IncludeStructuredData = include
+		result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/a", options)
+		if err != nil {
+			t.Fatalf("extract: %v", err)
+		}
+
+		want := 0
+		if include {
+			want = 2
+		}
+		if len(result.StructuredData) != want {
+			t.Errorf("IncludeStructuredData=%v: got %d objects, want %d", include, len(result.StructuredData), want)
+		}
+	}
+}
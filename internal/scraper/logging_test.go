@@ -0,0 +1,30 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want *slog.Logger
+	}{
+		{"request logger", WithLogger(context.Background(), logger), logger},
+		{"default without one", context.Background(), slog.Default()},
+		{"default for nil logger", WithLogger(context.Background(), nil), slog.Default()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoggerFromContext(tt.ctx); got != tt.want {
+				t.Errorf("LoggerFromContext() = %p, want %p", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package scraper
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DetectLoginWall reports whether a fetch of requestedURL landed on a login page
+// instead of the article: the final URL's path names a login page the requested
+// URL's doesn't, or the page asks for a password and has next to no article text
+// (wordCount under LoginWallMaxWords)
+func DetectLoginWall(html, requestedURL, finalURL string, wordCount int) bool {
+	if isLoginPath(finalURL) && !isLoginPath(requestedURL) {
+		return true
+	}
+
+	if wordCount >= LoginWallMaxWords {
+		return false
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+	return doc.Find(`input[type="password" i]`).Length() > 0
+}
+
+// isLoginPath reports whether a URL's path has a LoginPathSegments segment, extension
+// aside ("/account/login.php")
+func isLoginPath(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	for _, segment := range strings.Split(strings.ToLower(parsed.Path), "/") {
+		segment = strings.TrimSuffix(segment, path.Ext(segment))
+		for _, login := range LoginPathSegments {
+			if segment == login {
+				return true
+			}
+		}
+	}
+	return false
+}
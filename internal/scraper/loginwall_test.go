@@ -0,0 +1,33 @@
+package scraper
+
+import "testing"
+
+func TestDetectLoginWall(t *testing.T) {
+	const loginForm = `<html><body><form><input name="email"><input type="password" name="password"></form></body></html>`
+	const article = `<html><body><article><p>The council approved the new transit plan.</p></article></body></html>`
+
+	tests := []struct {
+		name      string
+		html      string
+		requested string
+		final     string
+		words     int
+		want      bool
+	}{
+		{"redirected to a login page", article, "https://example.com/news/story", "https://example.com/account/login?next=/news/story", 300, true},
+		{"redirected to a sign-in script", article, "https://example.com/news/story", "https://example.com/users/sign-in.php", 300, true},
+		{"login path requested", article, "https://example.com/blog/login", "https://example.com/blog/login", 300, false},
+		{"password form with little text", loginForm, "https://example.com/news/story", "https://example.com/news/story", 12, true},
+		{"password form on a full article", loginForm, "https://example.com/news/story", "https://example.com/news/story", 600, false},
+		{"login word elsewhere in the path", article, "https://example.com/news/story", "https://example.com/news/login-tips-for-seniors", 300, false},
+		{"plain article", article, "https://example.com/news/story", "https://example.com/news/story", 40, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLoginWall(tt.html, tt.requested, tt.final, tt.words); got != tt.want {
+				t.Errorf("DetectLoginWall = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
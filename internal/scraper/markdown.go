@@ -0,0 +1,151 @@
+package scraper
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// InlineImageFunc returns the absolute URL an inline image is rendered with, or "" to drop it
+type InlineImageFunc func(img *goquery.Selection) string
+
+// ExtractMarkdown renders the content subtree as Markdown: headings, paragraphs, list
+// items and blockquotes, keeping links and emphasis inline. Blocks wrapping other
+// blocks yield their inner blocks, as in ExtractParagraphs. Images are kept at their
+// position as ![alt](url) when imageURL is set, and dropped otherwise.
+func ExtractMarkdown(selection *goquery.Selection, imageURL InlineImageFunc) string {
+	var blocks []string
+
+	elements := TextElements
+	if imageURL != nil {
+		elements += ", " + ImageTags
+	}
+
+	selection.Find(elements).Each(func(i int, s *goquery.Selection) {
+		// Images inside a text block are rendered inline with it
+		if s.Is(ImageTags) {
+			if s.ParentsFiltered(TextElements).Length() == 0 {
+				if image := markdownImage(s, imageURL); image != "" {
+					blocks = append(blocks, image)
+				}
+			}
+			return
+		}
+
+		if s.Find(TextElements).Length() > 0 {
+			return
+		}
+
+		text := markdownInline(s, imageURL)
+		if text == "" {
+			return
+		}
+
+		switch tagName := goquery.NodeName(s); tagName {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			text = strings.Repeat("#", int(tagName[1]-'0')) + SingleSpace + text
+		case "li":
+			text = "- " + text
+		case "blockquote":
+			text = "> " + text
+		default:
+			// Inner blocks of a <blockquote> of <p>s stay quoted
+			if s.ParentsFiltered("blockquote").Length() > 0 {
+				text = "> " + text
+			}
+		}
+		blocks = append(blocks, text)
+	})
+
+	return strings.Join(blocks, DoubleNewline)
+}
+
+// markdownInline renders the inline content of a block with whitespace collapsed
+func markdownInline(s *goquery.Selection, imageURL InlineImageFunc) string {
+	var b strings.Builder
+	for _, node := range s.Nodes {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			writeMarkdownInline(&b, s, child, imageURL)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), SingleSpace)
+}
+
+// markdownImage renders an image as ![alt](url), or "" when it is dropped
+func markdownImage(img *goquery.Selection, imageURL InlineImageFunc) string {
+	if imageURL == nil {
+		return ""
+	}
+	src := imageURL(img)
+	if src == "" {
+		return ""
+	}
+
+	alt := strings.Join(strings.Fields(img.AttrOr("alt", "")), SingleSpace)
+	alt = strings.NewReplacer("[", "", "]", "").Replace(alt)
+	return "![" + alt + "](" + src + ")"
+}
+
+// writeMarkdownInline writes a node and its children, wrapping links, emphasis and code
+func writeMarkdownInline(b *strings.Builder, block *goquery.Selection, node *html.Node, imageURL InlineImageFunc) {
+	if node.Type == html.TextNode {
+		b.WriteString(node.Data)
+		return
+	}
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	var inner strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeMarkdownInline(&inner, block, child, imageURL)
+	}
+	raw := inner.String()
+	text := strings.TrimSpace(raw)
+
+	switch node.Data {
+	case "br":
+		b.WriteString(SingleSpace)
+		return
+	case "script", "style":
+		return
+	case "img", "amp-img":
+		if image := markdownImage(block.FindNodes(node), imageURL); image != "" {
+			b.WriteString(SingleSpace + image + SingleSpace)
+		}
+		return
+	}
+	if text == "" {
+		return
+	}
+
+	// Keep the whitespace around the wrapped text outside the markers
+	lead := raw[:len(raw)-len(strings.TrimLeftFunc(raw, unicode.IsSpace))]
+	trail := raw[len(strings.TrimRightFunc(raw, unicode.IsSpace)):]
+
+	switch node.Data {
+	case "a":
+		if href := markdownAttr(node, "href"); href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			text = "[" + text + "](" + href + ")"
+		}
+	case "strong", "b":
+		text = "**" + text + "**"
+	case "em", "i":
+		text = "_" + text + "_"
+	case "code":
+		text = "`" + text + "`"
+	}
+	b.WriteString(lead + text + trail)
+}
+
+// markdownAttr returns the trimmed value of an attribute on a raw HTML node
+func markdownAttr(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
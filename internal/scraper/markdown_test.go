@@ -0,0 +1,119 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "heading and paragraph",
+			html: `<h2>Budget</h2><p>The plan costs <strong>$4 million</strong>.</p>`,
+			want: "## Budget\n\nThe plan costs **$4 million**.",
+		},
+		{
+			name: "link and emphasis",
+			html: `<p>Read the <a href="https://example.com/report">full report</a>, <em>published today</em>.</p>`,
+			want: "Read the [full report](https://example.com/report), _published today_.",
+		},
+		{
+			name: "list items",
+			html: `<ul><li>Bus lanes</li><li>Bike paths</li></ul>`,
+			want: "- Bus lanes\n\n- Bike paths",
+		},
+		{
+			name: "blockquote of paragraphs",
+			html: `<blockquote><p>First line.</p><p>Second line.</p></blockquote>`,
+			want: "> First line.\n\n> Second line.",
+		},
+		{
+			name: "images dropped without a resolver",
+			html: `<p>Text.</p><img src="/photo.jpg" alt="Photo">`,
+			want: "Text.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<article>"+tt.html+"</article>")
+			if got := ExtractMarkdown(doc.Find("article"), nil); got != tt.want {
+				t.Errorf("ExtractMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludeMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		include bool
+	}{
+		{"off", false},
+		{"alongside text", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeMarkdown = tt.include
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(multiSectionPage, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			if !strings.Contains(result.Content, "first bus lanes") || strings.Contains(result.Content, "#") {
+				t.Errorf("content = %q, want plain text", result.Content)
+			}
+			if got := strings.Contains(result.ContentMarkdown, "first bus lanes"); got != tt.include {
+				t.Errorf("markdown = %q, want it only when included", result.ContentMarkdown)
+			}
+		})
+	}
+}
+
+// inlineImagePage has a content image between its second and third paragraphs
+var inlineImagePage = strings.Replace(multiSectionPage,
+	"<p>Residents who spoke",
+	`<figure><img src="/media/bus-lane.jpg" alt="New bus lane" width="1200" height="800"></figure><p>Residents who spoke`, 1)
+
+func TestKeepInlineImages(t *testing.T) {
+	const image = "![New bus lane](https://example.com/media/bus-lane.jpg)"
+
+	tests := []struct {
+		name      string
+		keep      bool
+		wantImage bool
+	}{
+		{"dropped by default", false, false},
+		{"kept at its position", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeMarkdown = true
+			options.KeepInlineImages = tt.keep
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(inlineImagePage, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			markdown := result.ContentMarkdown
+			index := strings.Index(markdown, image)
+			if got := index >= 0; got != tt.wantImage {
+				t.Fatalf("image in markdown = %v, want %v: %q", got, tt.wantImage, markdown)
+			}
+			if tt.wantImage && !(strings.Index(markdown, "first bus lanes") < index && index < strings.Index(markdown, "Residents who spoke")) {
+				t.Errorf("image out of place: %q", markdown)
+			}
+			if len(result.Images) == 0 {
+				t.Errorf("images = %v, want the image listed either way", result.Images)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package scraper
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MicrodataArticle holds the article fields found in inline schema.org microdata
+type MicrodataArticle struct {
+	Headline      string
+	Author        string
+	DatePublished string
+	DateModified  string
+	ArticleBody   string
+}
+
+// ExtractMicrodata reads itemprop="headline"/"author"/"datePublished"/"dateModified"/"articleBody"
+// from the document, taking the first non-empty value of each
+func ExtractMicrodata(doc *goquery.Document) MicrodataArticle {
+	return MicrodataArticle{
+		Headline:      firstItemprop(doc, "headline"),
+		Author:        firstItemprop(doc, "author"),
+		DatePublished: normalizeDate(firstItemprop(doc, "datePublished")),
+		DateModified:  normalizeDate(firstItemprop(doc, "dateModified")),
+		ArticleBody:   firstItemprop(doc, "articleBody"),
+	}
+}
+
+// firstItemprop returns the first non-empty value of the given itemprop
+func firstItemprop(doc *goquery.Document, name string) string {
+	value := ""
+	doc.Find(`[itemprop~="` + name + `"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		value = itempropValue(s)
+		return value == ""
+	})
+	return value
+}
+
+// itempropValue reads a microdata property value following the HTML microdata rules,
+// using the nested name for itemscope items such as an author Person
+func itempropValue(s *goquery.Selection) string {
+	if _, scoped := s.Attr("itemscope"); scoped {
+		if name := s.Find(`[itemprop~="name"]`).First(); name.Length() > 0 {
+			return itempropValue(name)
+		}
+	}
+
+	var value string
+	switch goquery.NodeName(s) {
+	case "meta":
+		value = s.AttrOr("content", "")
+	case "time":
+		value = firstAttr(s, "datetime")
+		if value == "" {
+			value = s.Text()
+		}
+	case "a", "link", "area":
+		value = s.AttrOr("href", "")
+	case "img", "audio", "video", "source":
+		value = s.AttrOr("src", "")
+	default:
+		value = s.Text()
+	}
+
+	return CleanWhitespace(strings.TrimSpace(value))
+}
+
+// normalizeDate formats recognizable dates like readability's publish dates, leaving others as-is
+func normalizeDate(value string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC().Format("2006-01-02T15:04:05Z")
+		}
+	}
+	return value
+}
+
+// localDate formats an RFC 3339 date keeping its original UTC offset, or returns "" for
+// dates without one, whose local time is unknown
+func localDate(value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return ""
+	}
+	return parsed.Format(time.RFC3339)
+}
+
+// localPublishDate finds the source date publishDate was normalized from among the
+// JSON-LD, meta tag and microdata publish dates, and returns it with its original offset
+func localPublishDate(doc *goquery.Document, jsonLD []map[string]interface{}, publishDate string) string {
+	if publishDate == "" {
+		return ""
+	}
+	for _, value := range []string{
+		JSONLDString(jsonLD, "datePublished"),
+		FindMetaTag(doc, "article:published_time", ""),
+		firstItemprop(doc, "datePublished"),
+	} {
+		if value != "" && normalizeDate(value) == publishDate {
+			if local := localDate(value); local != "" {
+				return local
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+// microdataPage marks its byline and dates only with itemprop microdata
+const microdataPage = `<html><head><title>Harbor Dredging Resumes</title></head><body>
+<article itemscope itemtype="https://schema.org/NewsArticle">
+<h1 itemprop="headline">Harbor Dredging Resumes</h1>
+<span itemprop="author" itemscope itemtype="https://schema.org/Person"><span itemprop="name">Maria Lopez</span></span>
+<time itemprop="datePublished" datetime="2024-03-05T08:30:00Z">March 5</time>
+<meta itemprop="dateModified" content="2024-03-06">
+<div itemprop="articleBody">
+<p>Dredging of the main shipping channel resumed on Monday after a winter pause caused by storms along the coast.</p>
+<p>Port officials expect the work to finish by June, allowing larger container ships to dock at the north terminal.</p>
+<p>Environmental groups have asked the port to monitor water quality near the oyster beds throughout the project.</p>
+</div>
+</article></body></html>`
+
+func TestExtractMicrodata(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want MicrodataArticle
+	}{
+		{
+			name: "article with nested author",
+			html: microdataPage,
+			want: MicrodataArticle{
+				Headline:      "Harbor Dredging Resumes",
+				Author:        "Maria Lopez",
+				DatePublished: "2024-03-05T08:30:00Z",
+				DateModified:  "2024-03-06T00:00:00Z",
+			},
+		},
+		{
+			name: "plain author text and skipped empty value",
+			html: `<p itemprop="author"></p><p itemprop="author">Sam Reed</p><meta itemprop="datePublished" content="2023-11-20">`,
+			want: MicrodataArticle{Author: "Sam Reed", DatePublished: "2023-11-20T00:00:00Z"},
+		},
+		{
+			name: "no microdata",
+			html: `<p>Just a paragraph.</p>`,
+			want: MicrodataArticle{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractMicrodata(parseDoc(t, tt.html))
+			got.ArticleBody = ""
+			if got != tt.want {
+				t.Errorf("ExtractMicrodata() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMicrodataFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		useMicrodata bool
+		wantAuthor   string
+		wantDate     string
+	}{
+		{"enabled", true, "Maria Lopez", "2024-03-05T08:30:00Z"},
+		// Readability reads itemprop="author" bylines itself, but not the dates
+		{"disabled", false, "Maria Lopez", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.UseMicrodata = tt.useMicrodata
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(microdataPage, "https://example.com/news/harbor", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			if result.Author != tt.wantAuthor || result.PublishDate != tt.wantDate {
+				t.Errorf("author %q, date %q, want %q, %q", result.Author, result.PublishDate, tt.wantAuthor, tt.wantDate)
+			}
+			if !strings.Contains(result.Content, "shipping channel") {
+				t.Errorf("content misses the article body: %q", result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package scraper
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var nextPageText = regexp.MustCompile(`(?i)^\s*(next(\s+page)?|more)?\s*[›»→>]*\s*$`)
+
+// FindNextPageURL finds the next page of a multi-page article via rel="next"
+// or a "next page" link inside a pagination block, restricted to the same host
+func FindNextPageURL(doc *goquery.Document, baseURL string) string {
+	candidates := []string{}
+
+	doc.Find(`link[rel~="next"], a[rel~="next"]`).Each(func(i int, s *goquery.Selection) {
+		if href, exists := s.Attr("href"); exists {
+			candidates = append(candidates, href)
+		}
+	})
+
+	doc.Find(PaginationSelectors).Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		class, _ := s.Attr("class")
+		text := strings.TrimSpace(s.Text())
+		if strings.Contains(strings.ToLower(class), "next") || (text != "" && nextPageText.MatchString(text)) {
+			href, _ := s.Attr("href")
+			candidates = append(candidates, href)
+		}
+	})
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+
+	for _, href := range candidates {
+		absURL, err := ResolveURL(href, baseURL)
+		if err != nil {
+			continue
+		}
+		parsed, err := url.Parse(absURL)
+		if err != nil || parsed.Hostname() != base.Hostname() {
+			continue
+		}
+		parsed.Fragment = ""
+		if next := parsed.String(); next != baseURL {
+			return next
+		}
+	}
+
+	return ""
+}
+
+// ExtractPageContent extracts the content of a follow-up page of a multi-page
+// article, dropping a repeated title line, and returns the page's own next link
+func (ae *ArticleExtractor) ExtractPageContent(html, pageURL, title string, options ExtractionOptions) (string, string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return "", ""
+	}
+
+	source := ae.resolveContentSource(doc, options)
+
+	var content string
+	if options.PreserveHTML {
+		inlineImage := inlineImageFunc(NewImageExtractorWithOptions(options), doc, pageURL, options)
+		content = ae.extractContentAsHTML(source.selection, inlineImage, options.SkipSanitization)
+	} else {
+		content = ae.extractContent(source.selection, options)
+		if title != "" {
+			firstLine, rest, _ := strings.Cut(content, SingleNewline)
+			if strings.EqualFold(strings.TrimSpace(strings.TrimLeft(firstLine, "#")), title) {
+				content = strings.TrimSpace(rest)
+			}
+		}
+	}
+
+	return content, FindNextPageURL(doc, ResolveBaseURL(doc, pageURL))
+}
+
+// NextPageURL parses the HTML and returns its next-page link, if any
+func (ae *ArticleExtractor) NextPageURL(html, pageURL string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ""
+	}
+	return FindNextPageURL(doc, ResolveBaseURL(doc, pageURL))
+}
+
+// mergePaginatedContent fetches following pages over HTTP and appends their content,
+// bounded by MaxPages (at most MaxPagesLimit) and the request deadline. A next link
+// leaving the first page's host ends the article.
+func (s *Scraper) mergePaginatedContent(ctx context.Context, result *models.ScrapeResponse, html, pageURL string, options ExtractionOptions) {
+	maxPages := options.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	if maxPages > MaxPagesLimit {
+		maxPages = MaxPagesLimit
+	}
+	// The first page already fills MaxContentLength
+	if result.Truncated {
+		return
+	}
+
+	firstPage, err := url.Parse(pageURL)
+	if err != nil {
+		return
+	}
+
+	separator := DoubleNewline
+	if options.PreserveHTML {
+		separator = SingleNewline
+	}
+
+	visited := map[string]bool{pageURL: true}
+	nextURL := s.extractor.NextPageURL(html, pageURL)
+	pages := 1
+
+	for nextURL != "" && !visited[nextURL] && pages < maxPages && ctx.Err() == nil {
+		visited[nextURL] = true
+
+		parsed, err := url.Parse(nextURL)
+		if err != nil || !strings.EqualFold(parsed.Hostname(), firstPage.Hostname()) || s.rateLimiter.Wait(ctx, parsed.Hostname()) != nil {
+			break
+		}
+
+		page, err := s.httpClient.Fetch(ctx, nextURL, options.fetchOptions().unconditional().scopedTo(pageURL, nextURL), 0)
+		if err != nil || page.HTML == "" {
+			break
+		}
+
+		var content string
+		content, nextURL = s.extractor.ExtractPageContent(page.HTML, nextURL, result.Title, options)
+		if content != "" {
+			result.Content += separator + content
+		}
+		pages++
+	}
+
+	result.Metadata.PageCount = pages
+	truncateContent(result, options)
+	setContentHashes(result)
+	setChunks(result, options)
+}
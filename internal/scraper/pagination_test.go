@@ -0,0 +1,119 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"extract-html-scraper/internal/models"
+)
+
+// pagedArticle serves /article/N pages, each linking the next with rel="next" up to pages
+func pagedArticle(pages int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		if _, err := fmt.Sscanf(r.URL.Path, "/article/%d", &n); err != nil || n < 1 || n > pages {
+			http.NotFound(w, r)
+			return
+		}
+
+		next := ""
+		if n < pages {
+			next = fmt.Sprintf(`<link rel="next" href="/article/%d">`, n+1)
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<html><head><title>Long Read</title>%s</head><body><article><h1>Long Read</h1>
+<p>Page %d opens with a paragraph long enough for the extractor to keep it as article content.</p>
+<p>Page %d goes on with a second paragraph so the page reads like a real part of the story.</p>
+</article></body></html>`, next, n, n)
+	}
+}
+
+func TestFindNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"link rel next", `<link rel="next" href="/story?page=2">`, "https://example.com/story?page=2"},
+		{"anchor rel next", `<a rel="next" href="page/2">Next</a>`, "https://example.com/page/2"},
+		{"pagination block", `<div class="pagination"><a href="/story/2">Next page »</a></div>`, "https://example.com/story/2"},
+		{"other host", `<link rel="next" href="https://other.example.org/story/2">`, ""},
+		{"self link", `<link rel="next" href="/story">`, ""},
+		{"none", `<p>The end</p>`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<html><head></head><body>"+tt.html+"</body></html>")
+			if got := FindNextPageURL(doc, "https://example.com/story"); got != tt.want {
+				t.Errorf("FindNextPageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePaginatedContent(t *testing.T) {
+	server := httptest.NewServer(pagedArticle(25))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		maxPages  int
+		wantPages int
+	}{
+		{"two pages", 2, 2},
+		{"default", 0, DefaultMaxPages},
+		{"clamped to the limit", 100, MaxPagesLimit},
+	}
+
+	s := newTestScraper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.FollowPagination = true
+			options.MaxPages = tt.maxPages
+
+			firstURL := server.URL + "/article/1"
+			fetched, err := s.httpClient.Fetch(context.Background(), firstURL, FetchOptions{}, 0)
+			if err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			result, err := s.extractor.ExtractArticleWithOptions(fetched.HTML, firstURL, options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			s.mergePaginatedContent(context.Background(), &result, fetched.HTML, firstURL, options)
+
+			if result.Metadata.PageCount != tt.wantPages {
+				t.Errorf("PageCount = %d, want %d", result.Metadata.PageCount, tt.wantPages)
+			}
+			first, second := strings.Index(result.Content, "Page 1 opens"), strings.Index(result.Content, "Page 2 opens")
+			if first < 0 || second < first {
+				t.Errorf("pages missing or out of order: %q", result.Content)
+			}
+			if got := strings.Count(result.Content, "Long Read"); got > 1 {
+				t.Errorf("title repeated %d times in %q", got, result.Content)
+			}
+		})
+	}
+}
+
+func TestMergePaginatedContentStaysOnSite(t *testing.T) {
+	offsite := httptest.NewServer(pagedArticle(2))
+	defer offsite.Close()
+
+	// 127.0.0.1 and localhost are different sites to sameSite
+	pageURL := strings.Replace(offsite.URL, "127.0.0.1", "localhost", 1) + "/article/1"
+	html := `<html><head><link rel="next" href="` + offsite.URL + `/article/2"></head><body><p>One</p></body></html>`
+
+	result := models.ScrapeResponse{Content: "One"}
+	newTestScraper().mergePaginatedContent(context.Background(), &result, html, pageURL, DefaultExtractionOptions())
+
+	if result.Metadata.PageCount != 1 || result.Content != "One" {
+		t.Errorf("followed an off-site next link: pages %d, content %q", result.Metadata.PageCount, result.Content)
+	}
+}
@@ -0,0 +1,37 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DetectPaywall reports whether the page looks truncated by a soft or metered paywall:
+// schema.org isAccessibleForFree=false, paywall container classes, or subscribe prompts
+// in the page text outside headers and footers
+func DetectPaywall(doc *goquery.Document) bool {
+	for _, object := range ExtractJSONLD(doc) {
+		if free, ok := object["isAccessibleForFree"]; ok && isFalse(free) {
+			return true
+		}
+	}
+
+	if doc.Find(PaywallSelectors).Length() > 0 {
+		return true
+	}
+
+	body := doc.Find("body").Clone()
+	body.Find(NonContentTags).Remove()
+	return ContainsAny(strings.Join(strings.Fields(body.Text()), SingleSpace), PaywallPhrases)
+}
+
+// isFalse accepts the JSON boolean false and the string "false" some CMSes emit
+func isFalse(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return !v
+	case string:
+		return strings.EqualFold(strings.TrimSpace(v), "false")
+	}
+	return false
+}
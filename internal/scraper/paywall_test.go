@@ -0,0 +1,52 @@
+package scraper
+
+import (
+	"testing"
+)
+
+func TestDetectPaywall(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{
+			name: "json-ld not free",
+			html: `<head><script type="application/ld+json">{"@type":"NewsArticle","isAccessibleForFree":false}</script></head><body><p>Teaser.</p></body>`,
+			want: true,
+		},
+		{
+			name: "json-ld string false",
+			html: `<head><script type="application/ld+json">{"@type":"NewsArticle","isAccessibleForFree":"False"}</script></head><body><p>Teaser.</p></body>`,
+			want: true,
+		},
+		{
+			name: "paywall container",
+			html: `<body><p>Teaser.</p><div class="article-paywall-gate">Log in</div></body>`,
+			want: true,
+		},
+		{
+			name: "subscribe prompt in the article",
+			html: `<body><p>Teaser.</p><p>Subscribe to continue reading this story.</p></body>`,
+			want: true,
+		},
+		{
+			name: "subscribe link in the footer only",
+			html: `<body><p>The whole story.</p><footer>Subscribe to read our newsletter</footer></body>`,
+			want: false,
+		},
+		{
+			name: "free article",
+			html: `<head><script type="application/ld+json">{"@type":"NewsArticle","isAccessibleForFree":true}</script></head><body><p>The whole story.</p></body>`,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectPaywall(parseDoc(t, "<html>"+tt.html+"</html>")); got != tt.want {
+				t.Errorf("DetectPaywall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
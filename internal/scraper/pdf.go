@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"extract-html-scraper/internal/models"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// ErrPDFNoText is returned for PDFs without a text layer, such as scanned documents
+var ErrPDFNoText = errors.New("PDF has no extractable text")
+
+// ExtractPDF extracts the text of a PDF into Content, taking Title from the document
+// info dictionary or, failing that, the first line of text
+func (ae *ArticleExtractor) ExtractPDF(data []byte, options ExtractionOptions) (response models.ScrapeResponse, err error) {
+	// The PDF parser panics on some malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			response, err = models.ScrapeResponse{}, fmt.Errorf("failed to parse PDF: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return models.ScrapeResponse{}, fmt.Errorf("failed to parse PDF: %w", err)
+	}
+
+	plain, err := reader.GetPlainText()
+	if err != nil {
+		return models.ScrapeResponse{}, fmt.Errorf("failed to extract PDF text: %w", err)
+	}
+	raw, err := io.ReadAll(plain)
+	if err != nil {
+		return models.ScrapeResponse{}, fmt.Errorf("failed to extract PDF text: %w", err)
+	}
+
+	content := ae.sanitizeText(CleanTextContent(string(raw)))
+	if strings.TrimSpace(content) == "" {
+		return models.ScrapeResponse{}, ErrPDFNoText
+	}
+
+	title := strings.TrimSpace(reader.Trailer().Key("Info").Key("Title").Text())
+	if title == "" {
+		title, _, _ = strings.Cut(content, SingleNewline)
+	}
+
+	wordCount, paragraphCount, avgParagraphLength := CalculateContentMetrics(content)
+	response = models.ScrapeResponse{
+		Title:   ae.sanitizeText(title),
+		Slug:    Slugify(title),
+		Content: content,
+		Images:  []string{},
+		Quality: models.Quality{
+			ParagraphCount:     paragraphCount,
+			AvgParagraphLength: avgParagraphLength,
+			WordCount:          wordCount,
+		},
+	}
+
+	if options.IncludeMetadata {
+		response.Author = ae.sanitizeText(strings.TrimSpace(reader.Trailer().Key("Info").Key("Author").Text()))
+		response.TextLength = len([]rune(content))
+		response.ReadingTime = EstimateReadingTime(content, "", options.WordsPerMinute, options.CharsPerMinute)
+	}
+
+	if options.IncludeDiagnostics {
+		response.Diagnostics = &models.Diagnostics{ExtractionMethod: ExtractionMethodPDF}
+	}
+
+	truncateContent(&response, options)
+	setContentHashes(&response)
+	setChunks(&response, options)
+	return response, nil
+}
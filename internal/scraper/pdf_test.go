@@ -0,0 +1,100 @@
+package scraper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// textPDF builds a one-page PDF drawing each line of text, with an optional document
+// info Title, computing the xref offsets the parser needs
+func textPDF(title string, lines []string) []byte {
+	var stream strings.Builder
+	stream.WriteString("BT /F1 12 Tf 72 720 Td 14 TL\n")
+	for _, line := range lines {
+		fmt.Fprintf(&stream, "(%s) Tj T*\n", line)
+	}
+	stream.WriteString("ET")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", stream.Len()+1, stream.String()),
+	}
+	info := ""
+	if title != "" {
+		objects = append(objects, fmt.Sprintf("<< /Title (%s) >>", title))
+		info = fmt.Sprintf(" /Info %d 0 R", len(objects))
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R%s >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, info, xref)
+	return b.Bytes()
+}
+
+func TestExtractPDF(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		wantTitle   string
+		wantContent string
+		wantErr     bool
+	}{
+		{
+			name:        "title from document info",
+			data:        textPDF("Annual Report", []string{"Revenue grew in every region this year."}),
+			wantTitle:   "Annual Report",
+			wantContent: "Revenue grew",
+		},
+		{
+			name:        "title from the first line",
+			data:        textPDF("", []string{"Costs fell for the third quarter in a row.", "Hiring stayed flat across the company."}),
+			wantTitle:   "Costs fell for the third quarter",
+			wantContent: "Hiring stayed flat",
+		},
+		{
+			name:    "not a pdf",
+			data:    []byte("<html>not a pdf</html>"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewArticleExtractor().ExtractPDF(tt.data, DefaultExtractionOptions())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ExtractPDF() succeeded with %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractPDF() error: %v", err)
+			}
+			if !strings.HasPrefix(result.Title, tt.wantTitle) || !strings.Contains(result.Content, tt.wantContent) {
+				t.Errorf("title %q, content %q", result.Title, result.Content)
+			}
+		})
+	}
+}
+
+func TestExtractPDFNoText(t *testing.T) {
+	if _, err := NewArticleExtractor().ExtractPDF(textPDF("Scanned", nil), DefaultExtractionOptions()); !errors.Is(err, ErrPDFNoText) {
+		t.Errorf("error = %v, want ErrPDFNoText", err)
+	}
+}
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// extractPreview reads only the link-preview fields from meta tags, JSON-LD and
+// microdata, skipping readability, content extraction and quality scoring
+func (ae *ArticleExtractor) extractPreview(doc *goquery.Document, baseURL string, options ExtractionOptions) models.ScrapeResponse {
+	jsonLD := ExtractJSONLD(doc)
+	microdata := ExtractMicrodata(doc)
+
+	imageExtractor := NewImageExtractorWithOptions(options)
+
+	response := models.ScrapeResponse{
+		Title:       ae.extractTitle(doc),
+		Description: ae.extractDescription(doc),
+		MainImage:   imageExtractor.PreviewImage(doc, baseURL),
+		Images:      []string{},
+		PublishDate: firstNonEmpty(
+			normalizeDate(JSONLDString(jsonLD, "datePublished")),
+			normalizeDate(FindMetaTag(doc, "article:published_time", "")),
+			microdata.DatePublished,
+		),
+		ModifiedDate: firstNonEmpty(
+			normalizeDate(JSONLDString(jsonLD, "dateModified")),
+			normalizeDate(FindMetaTag(doc, "article:modified_time", "")),
+			microdata.DateModified,
+		),
+	}
+
+	response.PublishDateLocal = localPublishDate(doc, jsonLD, response.PublishDate)
+	response.Slug = Slugify(response.Title)
+	if options.IncludeTitleCandidates {
+		response.TitleCandidates = ae.titleCandidates(doc)
+	}
+	if options.IncludeThumbnail && response.MainImage != "" {
+		response.ThumbnailURL = imageExtractor.ThumbnailURL(response.MainImage)
+	}
+	ae.setPageMetadata(&response, doc)
+
+	response.Authors = NewAuthorExtractor().ExtractAuthors(doc, firstNonEmpty(FindMetaTag(doc, "", "author"), microdata.Author))
+	if len(response.Authors) > 0 {
+		response.Author = response.Authors[0]
+	}
+
+	if options.IncludeStructuredData {
+		response.StructuredData = jsonLD
+	}
+	if options.IncludeMetaTags {
+		response.MetaTags = FindAllMetaTags(doc)
+	}
+
+	return response
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
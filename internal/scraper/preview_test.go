@@ -0,0 +1,52 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+// previewPage has link-preview metadata and a small og:image that the article image
+// filters would reject
+var previewPage = strings.Replace(multiSectionPage, "</head>", `<meta property="og:title" content="Transit Plan Approved">
+<meta property="og:description" content="The council backs new bus lanes.">
+<meta property="og:image" content="https://cdn.example.com/share.jpg">
+<meta property="og:image:width" content="200"><meta property="og:image:height" content="200">
+<meta property="article:published_time" content="2024-03-05T08:30:00Z">
+<meta name="author" content="Alice Martin">
+</head>`, 1)
+
+func TestPreview(t *testing.T) {
+	tests := []struct {
+		name        string
+		preview     bool
+		wantContent bool
+		wantImage   string
+	}{
+		{"full extraction", false, true, ""},
+		{"preview only", true, false, "https://cdn.example.com/share.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.Preview = tt.preview
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(previewPage, "https://example.com/news/transit", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+
+			if result.Title != "Transit Plan Approved" || result.Description != "The council backs new bus lanes." {
+				t.Errorf("title %q, description %q", result.Title, result.Description)
+			}
+			if result.PublishDate != "2024-03-05T08:30:00Z" || result.Author != "Alice Martin" {
+				t.Errorf("date %q, author %q", result.PublishDate, result.Author)
+			}
+			if got := result.Content != ""; got != tt.wantContent {
+				t.Errorf("content = %q, want content %v", result.Content, tt.wantContent)
+			}
+			if result.MainImage != tt.wantImage {
+				t.Errorf("main image = %q, want %q", result.MainImage, tt.wantImage)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package scraper
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HostRateLimiter is a per-host token bucket limiter, safe for concurrent use
+type HostRateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens per second
+	burst   float64
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewHostRateLimiter creates a limiter allowing rps requests per second per host
+// with the given burst. A non-positive rps disables limiting.
+func NewHostRateLimiter(rps float64, burst int) *HostRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &HostRateLimiter{
+		rate:    rps,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Wait delays until a request to host is allowed, returning early with the
+// context error if the deadline passes first
+func (l *HostRateLimiter) Wait(ctx context.Context, host string) error {
+	if l == nil || l.rate <= 0 || host == "" {
+		return nil
+	}
+
+	delay := l.reserve(host, time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token for host and returns how long the caller must wait for it
+func (l *HostRateLimiter) reserve(host string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, exists := l.buckets[host]
+	if !exists {
+		l.pruneIdle(now)
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[host] = bucket
+	}
+
+	// Refill since last use, capped at burst
+	bucket.tokens += now.Sub(bucket.last).Seconds() * l.rate
+	if bucket.tokens > l.burst {
+		bucket.tokens = l.burst
+	}
+	bucket.last = now
+
+	// Tokens may go negative: later callers queue behind earlier ones
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / l.rate * float64(time.Second))
+}
+
+// pruneIdle drops buckets that have fully refilled so the map doesn't grow unbounded
+func (l *HostRateLimiter) pruneIdle(now time.Time) {
+	if len(l.buckets) < MaxRateLimitedHosts {
+		return
+	}
+
+	for host, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, host)
+		}
+	}
+}
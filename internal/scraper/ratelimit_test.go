@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHostRateLimiterReserve(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name  string
+		host  string
+		at    time.Duration
+		delay time.Duration
+	}{
+		{"first request uses the burst", "a.example.com", 0, 0},
+		{"second request waits", "a.example.com", 0, 100 * time.Millisecond},
+		{"third request queues behind it", "a.example.com", 0, 200 * time.Millisecond},
+		{"other host is independent", "b.example.com", 0, 0},
+		{"refilled after a pause", "b.example.com", time.Second, 0},
+	}
+
+	limiter := NewHostRateLimiter(10, 1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limiter.reserve(tt.host, start.Add(tt.at)); got != tt.delay {
+				t.Errorf("reserve() = %v, want %v", got, tt.delay)
+			}
+		})
+	}
+}
+
+func TestHostRateLimiterSpacesRequests(t *testing.T) {
+	limiter := NewHostRateLimiter(20, 1)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background(), "example.com"); err != nil {
+				t.Errorf("Wait: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("two same-host requests took %v, want them spaced by ~50ms", elapsed)
+	}
+}
+
+func TestHostRateLimiterWait(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		limiter *HostRateLimiter
+		ctx     context.Context
+		wantErr bool
+	}{
+		{"disabled", NewHostRateLimiter(0, 1), canceled, false},
+		{"nil limiter", nil, canceled, false},
+		{"deadline before the token", NewHostRateLimiter(0.1, 1), canceled, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.limiter.Wait(context.Background(), "example.com")
+			if err := tt.limiter.Wait(tt.ctx, "example.com"); (err != nil) != tt.wantErr {
+				t.Errorf("Wait() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
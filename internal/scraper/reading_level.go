@@ -0,0 +1,83 @@
+package scraper
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"extract-html-scraper/internal/models"
+)
+
+// ScoreReadingLevel computes the Flesch Reading Ease and Flesch-Kincaid grade of the
+// text, or nil when it has no words. Syllables are counted from vowel groups, so the
+// scores are approximate and only meaningful for English.
+func ScoreReadingLevel(text string) *models.ReadingLevel {
+	words, syllables := 0, 0
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	}) {
+		words++
+		syllables += countSyllables(word)
+	}
+	if words == 0 {
+		return nil
+	}
+
+	sentences := countSentences(text)
+	wordsPerSentence := float64(words) / float64(sentences)
+	syllablesPerWord := float64(syllables) / float64(words)
+
+	return &models.ReadingLevel{
+		FleschReadingEase: roundTenth(206.835 - 1.015*wordsPerSentence - 84.6*syllablesPerWord),
+		GradeLevel:        roundTenth(math.Max(0, 0.39*wordsPerSentence+11.8*syllablesPerWord-15.59)),
+	}
+}
+
+// countSentences counts runs of text ending in terminal punctuation or a line break, at least one
+func countSentences(text string) int {
+	sentences := 0
+	inSentence := false
+	for _, r := range text {
+		switch {
+		case r == '.' || r == '!' || r == '?' || r == '\n':
+			if inSentence {
+				sentences++
+			}
+			inSentence = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			inSentence = true
+		}
+	}
+	if inSentence || sentences == 0 {
+		sentences++
+	}
+	return sentences
+}
+
+// countSyllables approximates a word's syllables as its vowel groups, not counting a
+// silent final "e", and at least one
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// roundTenth rounds to one decimal place
+func roundTenth(x float64) float64 {
+	return math.Round(x*10) / 10
+}
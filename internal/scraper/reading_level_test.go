@@ -0,0 +1,82 @@
+package scraper
+
+import "testing"
+
+func TestScoreReadingLevel(t *testing.T) {
+	const simple = "The cat sat on the mat. It was warm. The dog ran to the park."
+	const complex = "Comprehensive municipal infrastructure modernization necessitates considerable intergovernmental coordination, particularly regarding environmental sustainability requirements and administrative accountability."
+
+	easy := ScoreReadingLevel(simple)
+	hard := ScoreReadingLevel(complex)
+	if easy == nil || hard == nil {
+		t.Fatalf("scores = %v, %v", easy, hard)
+	}
+	if easy.FleschReadingEase <= hard.FleschReadingEase {
+		t.Errorf("reading ease simple %.1f, complex %.1f, want simple higher", easy.FleschReadingEase, hard.FleschReadingEase)
+	}
+	if easy.GradeLevel >= hard.GradeLevel {
+		t.Errorf("grade simple %.1f, complex %.1f, want simple lower", easy.GradeLevel, hard.GradeLevel)
+	}
+}
+
+func TestScoreReadingLevelEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"empty", ""},
+		{"punctuation only", "... !? --"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScoreReadingLevel(tt.text); got != nil {
+				t.Errorf("ScoreReadingLevel(%q) = %+v, want nil", tt.text, got)
+			}
+		})
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"cat", 1},
+		{"make", 1},
+		{"table", 2},
+		{"reading", 2},
+		{"beautiful", 3},
+		{"Hmm", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := countSyllables(tt.word); got != tt.want {
+				t.Errorf("countSyllables(%q) = %d, want %d", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountSentences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"one unterminated", "Just one sentence", 1},
+		{"terminal punctuation", "One. Two! Three?", 3},
+		{"ellipsis", "Wait... what?", 2},
+		{"line breaks", "Title\nBody text here.", 2},
+		{"no words", "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countSentences(tt.text); got != tt.want {
+				t.Errorf("countSentences(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package scraper
+
+import (
+	"fmt"
+	"strings"
+
+	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractRecipe reads a schema.org Recipe from JSON-LD, falling back to itemprop
+// microdata, or returns nil when the page has neither. Instructions grouped in
+// HowToSections are flattened into one list of steps.
+func ExtractRecipe(doc *goquery.Document) *models.Recipe {
+	for _, object := range ExtractJSONLD(doc) {
+		if jsonLDHasType(object, "Recipe") {
+			if recipe := recipeFromJSONLD(object); recipe != nil {
+				return recipe
+			}
+		}
+	}
+	return recipeFromMicrodata(doc)
+}
+
+// recipeFromJSONLD maps a Recipe object, or returns nil when it has no ingredients or steps
+func recipeFromJSONLD(object map[string]interface{}) *models.Recipe {
+	ingredients := jsonLDStrings(object["recipeIngredient"])
+	if len(ingredients) == 0 {
+		ingredients = jsonLDStrings(object["ingredients"])
+	}
+
+	// Yields often come as ["4", "4 servings"]; the longest says the most
+	yield := jsonLDFirstString(object["recipeYield"])
+	for _, value := range jsonLDStrings(object["recipeYield"]) {
+		if len(value) > len(yield) {
+			yield = value
+		}
+	}
+
+	recipe := &models.Recipe{
+		Name:         recipeText(jsonLDFirstString(object["name"])),
+		Ingredients:  recipeTexts(ingredients),
+		Instructions: recipeTexts(jsonLDInstructions(object["recipeInstructions"])),
+		TotalTime:    jsonLDFirstString(object["totalTime"]),
+		Yield:        yield,
+	}
+	if len(recipe.Ingredients) == 0 && len(recipe.Instructions) == 0 {
+		return nil
+	}
+	return recipe
+}
+
+// recipeFromMicrodata reads the first itemscope typed schema.org/Recipe
+func recipeFromMicrodata(doc *goquery.Document) *models.Recipe {
+	scope := doc.Find(`[itemscope][itemtype*="schema.org/Recipe"]`).First()
+	if scope.Length() == 0 {
+		return nil
+	}
+
+	recipe := &models.Recipe{
+		Name:      scopedItemprop(scope, "name"),
+		TotalTime: scopedItemprop(scope, "totalTime"),
+		Yield:     scopedItemprop(scope, "recipeYield"),
+	}
+
+	scope.Find(`[itemprop~="recipeIngredient"], [itemprop~="ingredients"]`).Each(func(i int, s *goquery.Selection) {
+		if text := itempropValue(s); text != "" {
+			recipe.Ingredients = append(recipe.Ingredients, text)
+		}
+	})
+
+	// Steps are either one itemprop per step, or one itemprop holding a list of them
+	scope.Find(`[itemprop~="recipeInstructions"]`).Each(func(i int, s *goquery.Selection) {
+		if steps := s.Find("li"); steps.Length() > 0 {
+			steps.Each(func(j int, step *goquery.Selection) {
+				if text := itempropValue(step); text != "" {
+					recipe.Instructions = append(recipe.Instructions, text)
+				}
+			})
+			return
+		}
+		if text := itempropValue(s); text != "" {
+			recipe.Instructions = append(recipe.Instructions, text)
+		}
+	})
+
+	if len(recipe.Ingredients) == 0 && len(recipe.Instructions) == 0 {
+		return nil
+	}
+	return recipe
+}
+
+// scopedItemprop returns the first non-empty value of an itemprop within an item
+func scopedItemprop(scope *goquery.Selection, name string) string {
+	value := ""
+	scope.Find(`[itemprop~="` + name + `"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		value = itempropValue(s)
+		return value == ""
+	})
+	return value
+}
+
+// jsonLDHasType reports whether an object's @type is, or lists, the given type
+func jsonLDHasType(object map[string]interface{}, typeName string) bool {
+	for _, t := range jsonLDStrings(object["@type"]) {
+		if t == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonLDStrings flattens a JSON-LD value that may be a string or a list of strings
+func jsonLDStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v = strings.TrimSpace(v); v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, jsonLDStrings(item)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// jsonLDFirstString returns the first value of a string, number or list, e.g. a
+// recipeYield given as 4, "4 servings" or ["4", "4 servings"]
+func jsonLDFirstString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return fmt.Sprint(v)
+	case []interface{}:
+		for _, item := range v {
+			if s := jsonLDFirstString(item); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// jsonLDInstructions flattens recipeInstructions: a text block, a list of texts,
+// HowToSteps, or HowToSections holding HowToSteps
+func jsonLDInstructions(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return jsonLDStrings(v)
+	case []interface{}:
+		var steps []string
+		for _, item := range v {
+			steps = append(steps, jsonLDInstructions(item)...)
+		}
+		return steps
+	case map[string]interface{}:
+		if items, ok := v["itemListElement"]; ok {
+			return jsonLDInstructions(items)
+		}
+		if text := jsonLDFirstString(v["text"]); text != "" {
+			return []string{text}
+		}
+		return jsonLDStrings(v["name"])
+	}
+	return nil
+}
+
+// recipeTexts cleans each value with recipeText, dropping empty ones
+func recipeTexts(values []string) []string {
+	var texts []string
+	for _, value := range values {
+		if text := recipeText(value); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// recipeText strips the markup and entities recipe plugins leave in JSON-LD strings
+func recipeText(value string) string {
+	if strings.ContainsAny(value, "<&") {
+		if fragment, err := goquery.NewDocumentFromReader(strings.NewReader(value)); err == nil {
+			value = fragment.Text()
+		}
+	}
+	return CleanWhitespace(strings.Join(strings.Fields(value), SingleSpace))
+}
@@ -0,0 +1,74 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+
+	"extract-html-scraper/internal/models"
+)
+
+func TestExtractRecipe(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want *models.Recipe
+	}{
+		{
+			name: "json-ld how-to steps",
+			html: `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Recipe","name":"Tomato Soup",
+"recipeIngredient":["4 tomatoes","1 onion","2 cups stock"],
+"recipeInstructions":[{"@type":"HowToStep","text":"Chop the tomatoes and onion."},{"@type":"HowToStep","text":"Simmer in stock for 20 minutes."}],
+"totalTime":"PT30M","recipeYield":["4","4 servings"]}</script>`,
+			want: &models.Recipe{
+				Name:         "Tomato Soup",
+				Ingredients:  []string{"4 tomatoes", "1 onion", "2 cups stock"},
+				Instructions: []string{"Chop the tomatoes and onion.", "Simmer in stock for 20 minutes."},
+				TotalTime:    "PT30M",
+				Yield:        "4 servings",
+			},
+		},
+		{
+			name: "json-ld sections in a graph",
+			html: `<script type="application/ld+json">{"@context":"https://schema.org","@graph":[{"@type":"WebPage"},{"@type":["Recipe","NewsArticle"],"name":"Flatbread",
+"recipeIngredient":"2 cups flour","recipeYield":6,
+"recipeInstructions":[{"@type":"HowToSection","name":"Dough","itemListElement":[{"@type":"HowToStep","text":"Mix."},{"@type":"HowToStep","text":"Knead."}]}]}]}</script>`,
+			want: &models.Recipe{
+				Name:         "Flatbread",
+				Ingredients:  []string{"2 cups flour"},
+				Instructions: []string{"Mix.", "Knead."},
+				Yield:        "6",
+			},
+		},
+		{
+			name: "microdata fallback",
+			html: `<div itemscope itemtype="https://schema.org/Recipe"><h1 itemprop="name">Lemonade</h1>
+<meta itemprop="totalTime" content="PT5M"><span itemprop="recipeYield">2 glasses</span>
+<ul><li itemprop="recipeIngredient">3 lemons</li><li itemprop="recipeIngredient">Sugar</li></ul>
+<ol itemprop="recipeInstructions"><li>Squeeze the lemons.</li><li>Stir in sugar and water.</li></ol></div>`,
+			want: &models.Recipe{
+				Name:         "Lemonade",
+				Ingredients:  []string{"3 lemons", "Sugar"},
+				Instructions: []string{"Squeeze the lemons.", "Stir in sugar and water."},
+				TotalTime:    "PT5M",
+				Yield:        "2 glasses",
+			},
+		},
+		{
+			name: "recipe without ingredients or steps",
+			html: `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Recipe","name":"Coming Soon"}</script>`,
+		},
+		{
+			name: "no recipe",
+			html: `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","headline":"Transit Plan"}</script>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractRecipe(parseDoc(t, "<html><head></head><body>"+tt.html+"</body></html>"))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractRecipe = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"extract-html-scraper/internal/models"
+)
+
+type redirectTraceKey struct{}
+
+// redirectTrace collects the hops of one fetch as the client follows redirects
+type redirectTrace struct {
+	mu      sync.Mutex
+	hops    []models.RedirectHop
+	hopFrom time.Time
+}
+
+// withRedirectTrace returns a context whose requests record their redirect hops
+func withRedirectTrace(ctx context.Context) (context.Context, *redirectTrace) {
+	trace := &redirectTrace{hopFrom: time.Now()}
+	return context.WithValue(ctx, redirectTraceKey{}, trace), trace
+}
+
+// recordRedirect is called from CheckRedirect with the response that redirected to req
+func recordRedirect(req *http.Request) {
+	trace, ok := req.Context().Value(redirectTraceKey{}).(*redirectTrace)
+	if !ok || req.Response == nil {
+		return
+	}
+	trace.add(req.Response.Request.URL.String(), req.Response.StatusCode)
+}
+
+// add appends a hop, timed from the end of the previous one
+func (t *redirectTrace) add(hopURL string, statusCode int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.hops = append(t.hops, models.RedirectHop{
+		URL:        hopURL,
+		StatusCode: statusCode,
+		DurationMs: now.Sub(t.hopFrom).Milliseconds(),
+	})
+	t.hopFrom = now
+}
+
+// finish records the final response and returns the whole chain
+func (t *redirectTrace) finish(resp *http.Response) []models.RedirectHop {
+	t.add(resp.Request.URL.String(), resp.StatusCode)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.hops
+}
@@ -5,11 +5,17 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
+	"extract-html-scraper/internal/config"
 	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/sync/errgroup"
 )
 
 // Scraper orchestrates the scraping process with HTTP-first, browser-fallback strategy
@@ -17,42 +23,322 @@ type Scraper struct {
 	httpClient    *HTTPClient
 	browserClient *BrowserClient
 	extractor     *ArticleExtractor
+	rateLimiter   *HostRateLimiter
+	maxAttempts   int           // Whole-scrape attempts, on top of the HTTP client's own retries
+	deadline      time.Duration // Caps each scrape, zero leaves it to the caller
+	httpsMode     string        // HTTPS mode for requests not setting their own
 }
 
 func NewScraper() *Scraper {
+	cfg := config.DefaultScrapeConfig()
+
 	return &Scraper{
 		httpClient:    NewHTTPClient(),
 		browserClient: NewBrowserClient(),
 		extractor:     NewArticleExtractor(),
+		rateLimiter:   NewHostRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst),
+		maxAttempts:   cfg.MaxAttempts,
+		deadline:      cfg.Deadline,
+		httpsMode:     cfg.HTTPSMode,
 	}
 }
 
 // ScrapeSmart implements the hybrid scraping strategy: HTTP first, browser fallback
 func (s *Scraper) ScrapeSmart(ctx context.Context, targetURL string) (models.ScrapeResponse, error) {
+	return s.ScrapeSmartWithOptions(ctx, targetURL, DefaultExtractionOptions())
+}
+
+// ScrapeSmartWithOptions runs the hybrid scraping strategy with per-request extraction options.
+// When both the HTTP and browser phases fail for a reason worth retrying, the whole
+// strategy runs again after ScrapeRetryDelay, up to SCRAPE_MAX_ATTEMPTS times in all,
+// as long as the context deadline leaves room for it. SCRAPE_DEADLINE, when set, caps
+// the whole scrape on top of the caller's deadline.
+func (s *Scraper) ScrapeSmartWithOptions(ctx context.Context, targetURL string, options ExtractionOptions) (models.ScrapeResponse, error) {
+	if s.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.deadline)
+		defer cancel()
+	}
+
+	result, err := s.scrapeOnce(ctx, targetURL, options)
+
+	for attempt := 2; attempt <= s.maxAttempts && isRetryableScrapeError(ctx, err); attempt++ {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < ScrapeRetryDelay {
+			break
+		}
+
+		LoggerFromContext(ctx).Warn("scrape failed, retrying", "attempt", attempt, "error", err)
+		select {
+		case <-time.After(ScrapeRetryDelay):
+		case <-ctx.Done():
+			return result, err
+		}
+		result, err = s.scrapeOnce(ctx, targetURL, options)
+	}
+
+	if err == nil && options.ValidateImages {
+		s.validateImages(ctx, &result, options)
+	}
+	if err == nil && options.IncludeMainImageSize {
+		s.probeMainImageSize(ctx, &result)
+	}
+	return result, err
+}
+
+// validateImages drops unreachable images, keeping the top DefaultImageLimit that pass.
+// An unreachable MainImage is replaced by the first image passing, and what was derived
+// from it is reset.
+func (s *Scraper) validateImages(ctx context.Context, result *models.ScrapeResponse, options ExtractionOptions) {
+	urls := append([]string(nil), result.Images...)
+	if result.MainImage != "" {
+		urls = append(urls, result.MainImage)
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, ImageValidationTimeout)
+	defer cancel()
+
+	reachable := make([]bool, len(urls))
+	var g errgroup.Group
+	g.SetLimit(ImageValidationConcurrency)
+	for i, imageURL := range urls {
+		g.Go(func() error {
+			reachable[i] = s.httpClient.ImageReachable(checkCtx, imageURL)
+			return nil
+		})
+	}
+	g.Wait()
+
+	images := []string{}
+	for i, imageURL := range result.Images {
+		if reachable[i] && len(images) < DefaultImageLimit {
+			images = append(images, imageURL)
+		}
+	}
+	result.Images = images
+
+	if result.MainImage != "" && !reachable[len(urls)-1] {
+		result.MainImage = ""
+		if len(images) > 0 {
+			result.MainImage = images[0]
+		}
+		result.MainImageWidth, result.MainImageHeight = 0, 0
+		result.ThumbnailURL = ""
+		if options.IncludeThumbnail && result.MainImage != "" {
+			result.ThumbnailURL = NewImageExtractor().ThumbnailURL(result.MainImage)
+		}
+	}
+	unreachable := 0
+	for _, ok := range reachable {
+		if !ok {
+			unreachable++
+		}
+	}
+	LoggerFromContext(ctx).Info("images validated", "path", PathHTTP, "checked", len(urls), "unreachable", unreachable)
+}
+
+// probeMainImageSize fills in a hero image size the page markup left out, typically an
+// og:image on a clean CDN URL. Failures leave the size unknown.
+func (s *Scraper) probeMainImageSize(ctx context.Context, result *models.ScrapeResponse) {
+	if result.MainImage == "" || (result.MainImageWidth > 0 && result.MainImageHeight > 0) {
+		return
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, ImageProbeTimeout)
+	defer cancel()
+
+	width, height, err := s.httpClient.ProbeImageSize(probeCtx, result.MainImage)
+	if err != nil {
+		LoggerFromContext(ctx).Warn("main image size probe failed", "path", PathHTTP, "error", err)
+		return
+	}
+	result.MainImageWidth, result.MainImageHeight = width, height
+}
+
+// isRetryableScrapeError reports a failed scrape that another attempt may fix: not a
+// bot block, an unchanged page, an unusable document or an expired deadline
+func isRetryableScrapeError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var cfErr *models.CloudflareBlockError
+	var extractionErr *models.ContentExtractionError
+	var loginErr *models.LoginRequiredError
+	switch {
+	case errors.As(err, &cfErr), errors.As(err, &extractionErr), errors.As(err, &loginErr):
+		return false
+	case errors.Is(err, ErrNotModified), errors.Is(err, ErrUnsupportedContentType), errors.Is(err, ErrPDFNoText), errors.Is(err, ErrHTTPSRequired):
+		return false
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return false
+	}
+	return !strings.HasPrefix(err.Error(), "invalid URL")
+}
+
+// phaseTimeout returns a phase's budget, cut down to the time left before ctx's
+// deadline so the phases together never outlast the caller's timeout
+func phaseTimeout(ctx context.Context, budget time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < budget {
+			return remaining
+		}
+	}
+	return budget
+}
+
+// ErrHTTPSRequired is returned for http:// targets in HTTPSModeRequire
+var ErrHTTPSRequired = errors.New("https required")
+
+// httpsTarget applies the request's HTTPS mode, else SCRAPE_HTTPS_MODE, to a target:
+// the URL to fetch, and the plain http URL to fall back to if an upgrade fails ("" when
+// there is none)
+func (s *Scraper) httpsTarget(targetURL string, options ExtractionOptions) (string, string, error) {
+	mode := options.HTTPSMode
+	if mode == "" {
+		mode = s.httpsMode
+	}
+	parsedURL, err := url.Parse(targetURL)
+	if err != nil || parsedURL.Scheme != "http" {
+		return targetURL, "", nil
+	}
+
+	switch mode {
+	case HTTPSModeRequire:
+		return "", "", fmt.Errorf("%w: %s", ErrHTTPSRequired, parsedURL.Redacted())
+	case HTTPSModeUpgrade:
+		parsedURL.Scheme = "https"
+		return parsedURL.String(), targetURL, nil
+	}
+	return targetURL, "", nil
+}
+
+// fetchHTTPS runs the HTTP fetch of an upgraded target, fetching the plain http
+// fallbackURL when https fails. It returns the URL the result came from.
+func (s *Scraper) fetchHTTPS(ctx context.Context, targetURL, fallbackURL string, opts FetchOptions) (*FetchResult, string, error) {
+	fetched, err := s.httpClient.FetchWithAlternatesOptions(ctx, targetURL, opts)
+	if err == nil || fallbackURL == "" || errors.Is(err, ErrNotModified) || ctx.Err() != nil {
+		return fetched, targetURL, err
+	}
+
+	LoggerFromContext(ctx).Info("https upgrade failed, falling back to http", "path", PathHTTP, "error", err)
+	fetched, err = s.httpClient.FetchWithAlternatesOptions(ctx, fallbackURL, opts)
+	return fetched, fallbackURL, err
+}
+
+// scrapeOnce runs the HTTP phase, then the browser fallback
+func (s *Scraper) scrapeOnce(ctx context.Context, targetURL string, options ExtractionOptions) (models.ScrapeResponse, error) {
 	// Validate URL
-	if _, err := url.Parse(targetURL); err != nil {
+	parsedURL, err := url.Parse(targetURL)
+	if err != nil {
 		return models.ScrapeResponse{}, fmt.Errorf("invalid URL: %w", err)
 	}
+	targetURL, fallbackURL, err := s.httpsTarget(targetURL, options)
+	if err != nil {
+		return models.ScrapeResponse{}, err
+	}
+
+	logger := LoggerFromContext(ctx)
 
 	// Phase 1: Try HTTP fetching with alternate URLs (18s budget)
-	httpCtx, cancel := context.WithTimeout(ctx, HTTPTimeout)
+	phaseStart := time.Now()
+
+	// Be polite to the target host
+	if err := s.rateLimiter.Wait(ctx, parsedURL.Hostname()); err != nil {
+		return models.ScrapeResponse{}, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	// Budgeted after the rate limit wait, as in renderAndExtract
+	httpCtx, cancel := context.WithTimeout(ctx, phaseTimeout(ctx, HTTPTimeout))
 	defer cancel()
 
-	html, finalURL, err := s.httpClient.FetchWithAlternatesGroup(httpCtx, targetURL)
+	// A failed https upgrade leaves the browser phase the plain http URL too
+	fetched, targetURL, err := s.fetchHTTPS(httpCtx, targetURL, fallbackURL, options.fetchOptions())
+	timing := models.Timing{HTTPFetchMs: time.Since(phaseStart).Milliseconds()}
 	if err == nil {
-		// Success with HTTP - extract content
-		result := s.extractor.ExtractArticle(html, finalURL)
+		logger.Info("fetch succeeded", "path", PathHTTP, "final_url", fetched.URL,
+			"duration_ms", timing.HTTPFetchMs)
+
+		// Success with HTTP - extract content; PDFs skip the HTML pipeline
+		extractStart := time.Now()
+		var result models.ScrapeResponse
+		if fetched.Body != nil {
+			result, err = s.extractor.ExtractPDF(fetched.Body, options)
+			if err != nil {
+				return models.ScrapeResponse{}, err
+			}
+		} else {
+			result, err = s.extractor.ExtractArticleWithOptions(fetched.HTML, fetched.URL, options)
+			if err != nil {
+				return models.ScrapeResponse{}, err
+			}
+			// A login form extracts as a short, useless article
+			if landedOnLoginWall(fetched.HTML, targetURL, fetched.URL, result, options) {
+				logger.Warn("landed on a login wall", "path", PathHTTP, "final_url", fetched.URL)
+				return models.ScrapeResponse{Images: []string{}}, &models.LoginRequiredError{URL: fetched.URL}
+			}
+			if options.FollowPagination && !options.Preview {
+				s.mergePaginatedContent(ctx, &result, fetched.HTML, fetched.URL, options)
+			}
+		}
+		timing.ExtractionMs = time.Since(extractStart).Milliseconds()
+		result.Metadata.Timing = &timing
+		result.Metadata.FinalURL = fetched.URL
+		result.Metadata.BodyTruncated = fetched.Truncated
+		result.Metadata.DetectedCharset = fetched.Charset
+		result.Metadata.ETag = fetched.Header.Get("ETag")
+		result.Metadata.LastModified = fetched.Header.Get("Last-Modified")
+		if options.IncludeResponseInfo {
+			setResponseInfo(&result.Metadata, fetched)
+		}
+		if options.IncludeRedirects {
+			result.Metadata.Redirects = fetched.Redirects
+		}
+
+		// The rendered page sometimes escapes a soft paywall the raw HTML hits
+		if result.Paywalled && options.PaywallFallback {
+			rendered, err := s.scrapeWithBrowser(ctx, targetURL, parsedURL.Hostname(), options)
+			if err == nil && (!rendered.Paywalled || rendered.Quality.WordCount > result.Quality.WordCount) {
+				logger.Info("paywalled http result replaced by browser result", "path", PathBrowser)
+				addHTTPTiming(&rendered, timing)
+				return rendered, nil
+			}
+		}
+
+		// Client-rendered pages can come back blank over HTTP
+		if isBlankContent(result) && fetched.Body == nil && !options.Preview {
+			rendered, err := s.scrapeWithBrowser(ctx, targetURL, parsedURL.Hostname(), options)
+			if err == nil && !isBlankContent(rendered) {
+				logger.Info("blank http result replaced by browser result", "path", PathBrowser)
+				addHTTPTiming(&rendered, timing)
+				return rendered, nil
+			}
+		}
 		return result, nil
 	}
 
-	// Phase 2: Browser fallback (40s budget)
-	browserCtx, cancel := context.WithTimeout(ctx, BrowserTimeout)
-	defer cancel()
+	// Unchanged since the caller's cached copy - nothing to extract
+	if errors.Is(err, ErrNotModified) {
+		logger.Info("upstream not modified", "path", PathHTTP,
+			"duration_ms", timing.HTTPFetchMs)
+		return models.ScrapeResponse{
+			Images: []string{},
+			Metadata: models.Metadata{
+				ETag:         fetched.Header.Get("ETag"),
+				LastModified: fetched.Header.Get("Last-Modified"),
+			},
+		}, err
+	}
+
+	logger.Warn("http fetch failed, falling back to browser", "path", PathHTTP, "error", err,
+		"duration_ms", timing.HTTPFetchMs)
 
-	html, finalURL, err = s.browserClient.ScrapeWithBrowserOptimized(browserCtx, targetURL, int(BrowserTimeout.Milliseconds()))
+	// Phase 2: Browser fallback (40s budget)
+	result, err := s.scrapeWithBrowser(ctx, targetURL, parsedURL.Hostname(), options)
 	if err == nil {
-		// Success with browser - extract content
-		result := s.extractor.ExtractArticle(html, finalURL)
+		result.Metadata.Timing.HTTPFetchMs = timing.HTTPFetchMs
 		return result, nil
 	}
 
@@ -70,6 +356,325 @@ func (s *Scraper) ScrapeSmart(ctx context.Context, targetURL string) (models.Scr
 	return models.ScrapeResponse{}, fmt.Errorf("scraping failed: %w", err)
 }
 
+// scrapeWithBrowser renders and extracts the page, rendering again with a longer settle
+// delay, up to BlankRetries times, while the content comes out blank from a hydration race
+func (s *Scraper) scrapeWithBrowser(ctx context.Context, targetURL, host string, options ExtractionOptions) (models.ScrapeResponse, error) {
+	result, err := s.renderAndExtract(ctx, targetURL, host, options)
+
+	for attempt := 1; err == nil && isBlankContent(result) && attempt <= options.blankRetries(); attempt++ {
+		options.SettleDelayMs = blankRetrySettleDelay(options.SettleDelayMs)
+		LoggerFromContext(ctx).Info("blank browser content, rendering again", "path", PathBrowser,
+			"attempt", attempt, "settle_delay_ms", options.SettleDelayMs)
+
+		retry, retryErr := s.renderAndExtract(ctx, targetURL, host, options)
+		if retryErr != nil {
+			break
+		}
+		retry.Metadata.Timing.BrowserMs += result.Metadata.Timing.BrowserMs
+		retry.Metadata.Timing.ExtractionMs += result.Metadata.Timing.ExtractionMs
+		result = retry
+	}
+
+	return result, err
+}
+
+// renderAndExtract renders the page in the browser (40s budget) and extracts it
+func (s *Scraper) renderAndExtract(ctx context.Context, targetURL, host string, options ExtractionOptions) (models.ScrapeResponse, error) {
+	logger := LoggerFromContext(ctx)
+	phaseStart := time.Now()
+
+	if err := s.rateLimiter.Wait(ctx, host); err != nil {
+		return models.ScrapeResponse{}, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	// Budgeted after the rate limit wait, which spends the same deadline
+	budget := phaseTimeout(ctx, BrowserTimeout)
+	browserCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	rendered, err := s.browserClient.ScrapeWithBrowserOptions(browserCtx, targetURL, int(budget.Milliseconds()), options.browserOptions())
+	if err != nil {
+		logger.Warn("browser fetch failed", "path", PathBrowser, "error", err,
+			"duration_ms", time.Since(phaseStart).Milliseconds())
+		return models.ScrapeResponse{}, err
+	}
+
+	timing := models.Timing{BrowserMs: time.Since(phaseStart).Milliseconds()}
+	logger.Info("fetch succeeded", "path", PathBrowser, "final_url", rendered.URL,
+		"duration_ms", timing.BrowserMs)
+
+	// Success with browser - extract content
+	extractStart := time.Now()
+	result, err := s.extractor.ExtractArticleWithOptions(rendered.HTML, rendered.URL, options)
+	if err != nil {
+		return models.ScrapeResponse{}, err
+	}
+	if landedOnLoginWall(rendered.HTML, targetURL, rendered.URL, result, options) {
+		logger.Warn("landed on a login wall", "path", PathBrowser, "final_url", rendered.URL)
+		return models.ScrapeResponse{Images: []string{}}, &models.LoginRequiredError{URL: rendered.URL}
+	}
+	if options.FollowPagination && !options.Preview {
+		s.mergePaginatedContent(ctx, &result, rendered.HTML, rendered.URL, options)
+	}
+	timing.ExtractionMs = time.Since(extractStart).Milliseconds()
+	result.Metadata.Timing = &timing
+	result.Metadata.FinalURL = rendered.URL
+	if result.Diagnostics != nil {
+		result.Diagnostics.BrowserUsed = true
+	}
+	if options.IncludeResponseInfo {
+		setResponseInfo(&result.Metadata, rendered)
+	}
+	return result, nil
+}
+
+// landedOnLoginWall applies DetectLoginWall to an extraction; previews count no words,
+// so only their final URL is checked
+func landedOnLoginWall(html, targetURL, finalURL string, result models.ScrapeResponse, options ExtractionOptions) bool {
+	if options.Preview {
+		return isLoginPath(finalURL) && !isLoginPath(targetURL)
+	}
+	return DetectLoginWall(html, targetURL, finalURL, result.Quality.WordCount)
+}
+
+// isBlankContent reports an extraction that produced no article text
+func isBlankContent(result models.ScrapeResponse) bool {
+	return strings.TrimSpace(result.Content) == ""
+}
+
+// blankRetrySettleDelay doubles the settle delay for a re-render, to at least BlankRetrySettleDelay
+func blankRetrySettleDelay(settleDelayMs int) int {
+	next := settleDelayMs * 2
+	if minimum := int(BlankRetrySettleDelay.Milliseconds()); next < minimum {
+		next = minimum
+	}
+	return next
+}
+
+// addHTTPTiming folds the HTTP phase of a replaced HTTP result into a browser result's timing
+func addHTTPTiming(rendered *models.ScrapeResponse, timing models.Timing) {
+	rendered.Metadata.Timing.HTTPFetchMs = timing.HTTPFetchMs
+	rendered.Metadata.Timing.ExtractionMs += timing.ExtractionMs
+}
+
+// setResponseInfo copies the upstream status and allowlisted response headers into metadata
+func setResponseInfo(metadata *models.Metadata, fetched *FetchResult) {
+	metadata.StatusCode = fetched.StatusCode
+	for _, name := range ResponseInfoHeaders {
+		value := fetched.Header.Get(name)
+		if value == "" {
+			continue
+		}
+		if metadata.Headers == nil {
+			metadata.Headers = make(map[string]string)
+		}
+		metadata.Headers[name] = value
+	}
+}
+
+// FetchRaw fetches the page the way ScrapeSmart does, over HTTP with a browser fallback,
+// and returns the HTML without extraction, sanitization or scoring
+func (s *Scraper) FetchRaw(ctx context.Context, targetURL string, options ExtractionOptions) (*FetchResult, error) {
+	parsedURL, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	targetURL, fallbackURL, err := s.httpsTarget(targetURL, options)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := LoggerFromContext(ctx)
+	phaseStart := time.Now()
+
+	if err := s.rateLimiter.Wait(ctx, parsedURL.Hostname()); err != nil {
+		return nil, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	httpCtx, cancel := context.WithTimeout(ctx, phaseTimeout(ctx, HTTPTimeout))
+	defer cancel()
+
+	// Raw mode returns HTML, so PDFs stay unsupported content
+	fetchOptions := options.fetchOptions()
+	fetchOptions.AcceptPDF = false
+
+	fetched, targetURL, err := s.fetchHTTPS(httpCtx, targetURL, fallbackURL, fetchOptions)
+	if err == nil {
+		logger.Info("fetch succeeded", "path", PathHTTP, "final_url", fetched.URL,
+			"duration_ms", time.Since(phaseStart).Milliseconds())
+		return fetched, nil
+	}
+
+	// Unchanged since the caller's cached copy
+	if errors.Is(err, ErrNotModified) {
+		logger.Info("upstream not modified", "path", PathHTTP,
+			"duration_ms", time.Since(phaseStart).Milliseconds())
+		return fetched, err
+	}
+
+	logger.Warn("http fetch failed, falling back to browser", "path", PathHTTP, "error", err,
+		"duration_ms", time.Since(phaseStart).Milliseconds())
+
+	phaseStart = time.Now()
+	if err := s.rateLimiter.Wait(ctx, parsedURL.Hostname()); err != nil {
+		return nil, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	budget := phaseTimeout(ctx, BrowserTimeout)
+	browserCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	rendered, err := s.browserClient.ScrapeWithBrowserOptions(browserCtx, targetURL, int(budget.Milliseconds()), options.browserOptions())
+	if err != nil {
+		logger.Warn("browser fetch failed", "path", PathBrowser, "error", err,
+			"duration_ms", time.Since(phaseStart).Milliseconds())
+		return nil, fmt.Errorf("fetch failed: %w", err)
+	}
+
+	logger.Info("fetch succeeded", "path", PathBrowser, "final_url", rendered.URL,
+		"duration_ms", time.Since(phaseStart).Milliseconds())
+	return rendered, nil
+}
+
+// Validate checks that a URL is reachable and serves HTML, without extraction or browser fallback
+func (s *Scraper) Validate(ctx context.Context, targetURL string) (models.ValidateResponse, error) {
+	parsedURL, err := url.Parse(targetURL)
+	if err != nil {
+		return models.ValidateResponse{}, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	probeURL, fallbackURL, err := s.httpsTarget(targetURL, ExtractionOptions{})
+	if err != nil {
+		return models.ValidateResponse{}, err
+	}
+
+	if err := s.rateLimiter.Wait(ctx, parsedURL.Hostname()); err != nil {
+		return models.ValidateResponse{}, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, HTTPTimeout)
+	defer cancel()
+
+	probe, err := s.httpClient.Probe(probeCtx, probeURL)
+	if err != nil && fallbackURL != "" && probeCtx.Err() == nil {
+		LoggerFromContext(ctx).Info("https upgrade failed, falling back to http", "path", PathHTTP, "error", err)
+		probe, err = s.httpClient.Probe(probeCtx, fallbackURL)
+	}
+	if err != nil {
+		return models.ValidateResponse{}, err
+	}
+
+	LoggerFromContext(ctx).Info("probe completed", "path", PathHTTP, "status", probe.StatusCode, "blocked", probe.Blocked)
+
+	return models.ValidateResponse{
+		URL:         targetURL,
+		FinalURL:    probe.URL,
+		StatusCode:  probe.StatusCode,
+		ContentType: probe.ContentType,
+		IsHTML:      s.httpClient.AcceptsContentType(probe.ContentType),
+		Blocked:     probe.Blocked,
+	}, nil
+}
+
+// DiscoverOptions carries per-request discovery inputs
+type DiscoverOptions struct {
+	// ExpandSitemaps fetches the first MaxExpandedSitemaps declared sitemaps, gzipped
+	// or not, and returns the page URLs and child sitemaps they list
+	ExpandSitemaps bool
+}
+
+// Discover fetches a site's homepage and robots.txt in parallel and returns the
+// RSS/Atom feeds and sitemaps they declare
+func (s *Scraper) Discover(ctx context.Context, siteURL string) (models.DiscoverResponse, error) {
+	return s.DiscoverWithOptions(ctx, siteURL, DiscoverOptions{})
+}
+
+// DiscoverWithOptions runs Discover with per-request discovery options
+func (s *Scraper) DiscoverWithOptions(ctx context.Context, siteURL string, options DiscoverOptions) (models.DiscoverResponse, error) {
+	parsedURL, err := url.Parse(siteURL)
+	if err != nil || parsedURL.Host == "" {
+		return models.DiscoverResponse{}, fmt.Errorf("invalid URL: %s", siteURL)
+	}
+
+	siteURL, fallbackURL, err := s.httpsTarget(siteURL, ExtractionOptions{})
+	if err != nil {
+		return models.DiscoverResponse{}, err
+	}
+
+	if err := s.rateLimiter.Wait(ctx, parsedURL.Hostname()); err != nil {
+		return models.DiscoverResponse{}, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	discoverCtx, cancel := context.WithTimeout(ctx, HTTPTimeout)
+	defer cancel()
+
+	result, err := s.discover(discoverCtx, siteURL, options)
+	if err != nil && fallbackURL != "" && discoverCtx.Err() == nil {
+		LoggerFromContext(ctx).Info("https upgrade failed, falling back to http", "path", PathHTTP, "error", err)
+		result, err = s.discover(discoverCtx, fallbackURL, options)
+	}
+	return result, err
+}
+
+// discover fetches the homepage and robots.txt of siteURL's host
+func (s *Scraper) discover(ctx context.Context, siteURL string, options DiscoverOptions) (models.DiscoverResponse, error) {
+	parsedURL, err := url.Parse(siteURL)
+	if err != nil {
+		return models.DiscoverResponse{}, fmt.Errorf("invalid URL: %s", siteURL)
+	}
+
+	homeURL := (&url.URL{Scheme: parsedURL.Scheme, Host: parsedURL.Host, Path: "/"}).String()
+	robotsURL := (&url.URL{Scheme: parsedURL.Scheme, Host: parsedURL.Host, Path: "/robots.txt"}).String()
+
+	var homeErr, robotsErr error
+	result := models.DiscoverResponse{URL: homeURL, Feeds: []string{}, Sitemaps: []string{}}
+	var g errgroup.Group
+
+	g.Go(func() error {
+		fetched, err := s.httpClient.Fetch(ctx, homeURL, FetchOptions{}, 0)
+		if err != nil {
+			homeErr = err
+			return nil
+		}
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(fetched.HTML))
+		if err != nil {
+			homeErr = err
+			return nil
+		}
+		if feeds := FindFeedLinks(doc, fetched.URL); len(feeds) > 0 {
+			result.Feeds = feeds
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		robots, err := s.httpClient.FetchText(ctx, robotsURL)
+		if err != nil {
+			robotsErr = err
+			return nil
+		}
+		if sitemaps := ParseRobotsSitemaps(robots, robotsURL); len(sitemaps) > 0 {
+			result.Sitemaps = sitemaps
+		}
+		if options.ExpandSitemaps {
+			result.Pages, result.ChildSitemaps = s.expandSitemaps(ctx, result.Sitemaps)
+		}
+		return nil
+	})
+
+	g.Wait()
+
+	LoggerFromContext(ctx).Info("discovery completed", "path", PathHTTP, "feeds", len(result.Feeds),
+		"sitemaps", len(result.Sitemaps), "pages", len(result.Pages), "home_error", homeErr, "robots_error", robotsErr)
+
+	// Either source alone is still useful
+	if homeErr != nil && robotsErr != nil {
+		return models.DiscoverResponse{}, fmt.Errorf("discovery failed: homepage: %v; robots.txt: %w", homeErr, robotsErr)
+	}
+
+	return result, nil
+}
+
 // ScrapeSmartWithTimeout runs ScrapeSmart with a timeout
 func (s *Scraper) ScrapeSmartWithTimeout(ctx context.Context, targetURL string, timeoutMs int) (models.ScrapeResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
@@ -77,3 +682,47 @@ func (s *Scraper) ScrapeSmartWithTimeout(ctx context.Context, targetURL string,
 
 	return s.ScrapeSmart(ctx, targetURL)
 }
+
+// expandSitemaps fetches the first MaxExpandedSitemaps sitemaps in parallel and merges
+// the page URLs (up to MaxSitemapPages) and child sitemaps they list. Sitemaps that
+// fail to fetch or parse are skipped.
+func (s *Scraper) expandSitemaps(ctx context.Context, sitemapURLs []string) (pages, children []string) {
+	if len(sitemapURLs) > MaxExpandedSitemaps {
+		sitemapURLs = sitemapURLs[:MaxExpandedSitemaps]
+	}
+
+	type listing struct{ pages, children []string }
+	listings := make([]listing, len(sitemapURLs))
+
+	var g errgroup.Group
+	for i, sitemapURL := range sitemapURLs {
+		g.Go(func() error {
+			data, err := s.httpClient.FetchSitemap(ctx, sitemapURL)
+			if err == nil {
+				listings[i].pages, listings[i].children, err = ParseSitemap(data, s.httpClient.config.SizeLimitBytes)
+			}
+			if err != nil {
+				LoggerFromContext(ctx).Warn("sitemap skipped", "path", PathHTTP, "sitemap", sitemapURL, "error", err)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	seen := make(map[string]bool)
+	for _, l := range listings {
+		for _, page := range l.pages {
+			if len(pages) < MaxSitemapPages && !seen[page] {
+				seen[page] = true
+				pages = append(pages, page)
+			}
+		}
+		for _, child := range l.children {
+			if !seen[child] {
+				seen[child] = true
+				children = append(children, child)
+			}
+		}
+	}
+	return pages, children
+}
@@ -0,0 +1,661 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"extract-html-scraper/internal/models"
+)
+
+func TestScrapeNotModified(t *testing.T) {
+	server := conditionalServer()
+	defer server.Close()
+
+	options := DefaultExtractionOptions()
+	options.IfNoneMatch = `"v1"`
+	result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL, options)
+
+	if !errors.Is(err, ErrNotModified) {
+		t.Fatalf("err = %v, want ErrNotModified", err)
+	}
+	if result.Metadata.ETag != `"v1"` || result.Metadata.LastModified == "" {
+		t.Errorf("validators not passed back: %+v", result.Metadata)
+	}
+	if result.Content != "" {
+		t.Errorf("content extracted from a 304: %q", result.Content)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, articleHTML("Reachable"))
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG"))
+	})
+	mux.HandleFunc("/blocked", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("cf-mitigated", "challenge")
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		wantStatus  int
+		wantHTML    bool
+		wantBlocked bool
+		wantFinal   string
+	}{
+		{"html page", "/page", http.StatusOK, true, false, "/page"},
+		{"image", "/image.png", http.StatusOK, false, false, "/image.png"},
+		{"bot wall", "/blocked", http.StatusForbidden, true, true, "/blocked"},
+		{"redirect", "/old", http.StatusOK, true, false, "/page"},
+		{"not found", "/missing", http.StatusNotFound, false, false, "/missing"},
+	}
+
+	s := newTestScraper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Validate(context.Background(), server.URL+tt.path)
+			if err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			if got.StatusCode != tt.wantStatus || got.IsHTML != tt.wantHTML || got.Blocked != tt.wantBlocked {
+				t.Errorf("got status %d, html %v, blocked %v; want %d, %v, %v",
+					got.StatusCode, got.IsHTML, got.Blocked, tt.wantStatus, tt.wantHTML, tt.wantBlocked)
+			}
+			if got.FinalURL != server.URL+tt.wantFinal {
+				t.Errorf("FinalURL = %q, want %q", got.FinalURL, server.URL+tt.wantFinal)
+			}
+		})
+	}
+
+	server.Close()
+	if _, err := s.Validate(context.Background(), server.URL+"/page"); err == nil {
+		t.Error("Validate of a closed server succeeded")
+	}
+}
+
+// articleServer serves articleHTML at every path, with the given extra response headers
+func articleServer(title string, header http.Header) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, articleHTML(title))
+	}))
+}
+
+func TestScrapeFinalURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, articleHTML("Moved"))
+	})
+	mux.HandleFunc("/blocked", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/amp/blocked", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, articleHTML("AMP"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		wantFinal string
+		wantTitle string
+	}{
+		{"redirect", "/old", "/article", "Moved"},
+		{"amp alternate", "/blocked", "/amp/blocked", "AMP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL+tt.path, DefaultExtractionOptions())
+			if err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+			if result.Metadata.FinalURL != server.URL+tt.wantFinal {
+				t.Errorf("FinalURL = %q, want %q", result.Metadata.FinalURL, server.URL+tt.wantFinal)
+			}
+			if result.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", result.Title, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestIncludeResponseInfo(t *testing.T) {
+	server := articleServer("Info", http.Header{"Server": {"test-server"}, "X-Private": {"secret"}})
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		include bool
+	}{
+		{"included", true},
+		{"off by default", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.IncludeResponseInfo = tt.include
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL, options)
+			if err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+
+			metadata := result.Metadata
+			if !tt.include {
+				if metadata.StatusCode != 0 || metadata.Headers != nil {
+					t.Errorf("response info without the option: %d %v", metadata.StatusCode, metadata.Headers)
+				}
+				return
+			}
+			if metadata.StatusCode != http.StatusOK {
+				t.Errorf("StatusCode = %d, want 200", metadata.StatusCode)
+			}
+			if got := metadata.Headers["Content-Type"]; got != "text/html; charset=utf-8" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if metadata.Headers["Server"] != "test-server" || metadata.Headers["X-Private"] != "" {
+				t.Errorf("headers not limited to the allowlist: %v", metadata.Headers)
+			}
+		})
+	}
+}
+
+func TestBodyTruncatedReported(t *testing.T) {
+	server := articleServer("Long Read", nil)
+	defer server.Close()
+	size := len(articleHTML("Long Read"))
+
+	tests := []struct {
+		name         string
+		maxBodyBytes int
+		want         bool
+	}{
+		{"fits", 0, false},
+		{"cut at the override", size - 20, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.MaxBodyBytes = tt.maxBodyBytes
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL, options)
+			if err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+			if result.Metadata.BodyTruncated != tt.want {
+				t.Errorf("BodyTruncated = %v, want %v", result.Metadata.BodyTruncated, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxContentLength(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxLength     int
+		wantTruncated bool
+	}{
+		{"unlimited", 0, false},
+		{"longer than the content", 5000, false},
+		{"cut at a sentence", 150, true},
+	}
+
+	server := articleServer("Long Story", nil)
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.MaxContentLength = tt.maxLength
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL, options)
+			if err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+
+			if result.Truncated != tt.wantTruncated {
+				t.Fatalf("truncated = %v, want %v", result.Truncated, tt.wantTruncated)
+			}
+			if tt.wantTruncated && (len([]rune(result.Content)) > tt.maxLength || !strings.HasSuffix(result.Content, ".")) {
+				t.Errorf("content = %q, want at most %d characters ending a sentence", result.Content, tt.maxLength)
+			}
+			if result.ContentHash != HashContent(result.Content) {
+				t.Errorf("content hash not recomputed for the returned content")
+			}
+		})
+	}
+}
+
+func TestBlankRetrySettleDelay(t *testing.T) {
+	minimum := int(BlankRetrySettleDelay.Milliseconds())
+
+	tests := []struct {
+		name  string
+		delay int
+		want  int
+	}{
+		{"no delay gets the minimum", 0, minimum},
+		{"short delay gets the minimum", 500, minimum},
+		{"long delay doubles", 3000, 6000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blankRetrySettleDelay(tt.delay); got != tt.want {
+				t.Errorf("blankRetrySettleDelay(%d) = %d, want %d", tt.delay, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlankRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		retries int
+		want    int
+	}{
+		{"disabled", 0, 0},
+		{"requested", 2, 2},
+		{"capped", 10, MaxBlankRetries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.BlankRetries = tt.retries
+			if got := options.blankRetries(); got != tt.want {
+				t.Errorf("blankRetries() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBlankContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"empty", "", true},
+		{"whitespace from an unhydrated shell", "\n  \n", true},
+		{"populated", "The council approved the plan.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBlankContent(models.ScrapeResponse{Content: tt.content}); got != tt.want {
+				t.Errorf("isBlankContent(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryableScrapeError(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"success", context.Background(), nil, false},
+		{"upstream failure", context.Background(), errors.New("scraping failed: HTTP 404"), true},
+		{"bot block", context.Background(), &models.CloudflareBlockError{Domain: "example.com"}, false},
+		{"unextractable", context.Background(), &models.ContentExtractionError{Step: "parse", Err: ErrEmptyDocument}, false},
+		{"not modified", context.Background(), ErrNotModified, false},
+		{"unsupported content", context.Background(), fmt.Errorf("%w: %q", ErrUnsupportedContentType, "image/png"), false},
+		{"deadline", context.Background(), fmt.Errorf("fetch: %w", context.DeadlineExceeded), false},
+		{"invalid url", context.Background(), errors.New("invalid URL: ::"), false},
+		{"context done", cancelled, errors.New("scraping failed: HTTP 404"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableScrapeError(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("isRetryableScrapeError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScrapeRetriesWholeAttempt(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		wantErr     bool
+	}{
+		{"single attempt fails", 1, true},
+		{"second attempt succeeds", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "text/html")
+				fmt.Fprint(w, articleHTML("Second Attempt"))
+			}))
+			defer server.Close()
+
+			s := newTestScraper()
+			s.maxAttempts = tt.maxAttempts
+			result, err := s.ScrapeSmartWithOptions(context.Background(), server.URL, DefaultExtractionOptions())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scrape error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && result.Title != "Second Attempt" {
+				t.Errorf("title = %q", result.Title)
+			}
+		})
+	}
+}
+
+func TestMainImageSizeProbe(t *testing.T) {
+	hero := encodedImage(t, 1200, 630, func(b *bytes.Buffer, m image.Image) error { return png.Encode(b, m) })
+
+	tests := []struct {
+		name       string
+		include    bool
+		ogSize     string
+		wantWidth  int
+		wantHeight int
+		wantProbes int32
+	}{
+		{"off", false, "", 0, 0, 0},
+		{"dimensionless og:image probed", true, "", 1200, 630, 1},
+		{"declared size kept", true, `<meta property="og:image:width" content="1600"><meta property="og:image:height" content="900">`, 1600, 900, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var probes atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/media/hero.png", func(w http.ResponseWriter, r *http.Request) {
+				probes.Add(1)
+				w.Header().Set("Content-Type", "image/png")
+				w.Write(hero)
+			})
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				head := `<meta property="og:image" content="/media/hero.png">` + tt.ogSize
+				fmt.Fprint(w, strings.Replace(articleHTML("Hero"), "</head>", head+"</head>", 1))
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			options := DefaultExtractionOptions()
+			options.IncludeMainImageSize = tt.include
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL+"/news/hero", options)
+			if err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+			if result.MainImage != server.URL+"/media/hero.png" {
+				t.Fatalf("main image = %q", result.MainImage)
+			}
+			if result.MainImageWidth != tt.wantWidth || result.MainImageHeight != tt.wantHeight {
+				t.Errorf("main image size = %dx%d, want %dx%d", result.MainImageWidth, result.MainImageHeight, tt.wantWidth, tt.wantHeight)
+			}
+			if got := probes.Load(); got != tt.wantProbes {
+				t.Errorf("image requests = %d, want %d", got, tt.wantProbes)
+			}
+		})
+	}
+}
+
+func TestValidateImages(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/media/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		images := `<img src="/media/missing-hero.jpg" width="1600" height="900"><img src="/media/transit.jpg" width="1200" height="630">`
+		fmt.Fprint(w, strings.Replace(articleHTML("Images"), "</article>", images+"</article>", 1))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		validate bool
+		want     []string
+	}{
+		{"off", false, []string{"/media/missing-hero.jpg", "/media/transit.jpg"}},
+		{"missing image excluded", true, []string{"/media/transit.jpg"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.ValidateImages = tt.validate
+			options.IncludeThumbnail = true
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL+"/news/transit", options)
+			if err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+
+			want := make([]string, len(tt.want))
+			for i, path := range tt.want {
+				want[i] = server.URL + path
+			}
+			if !equalStrings(result.Images, want) {
+				t.Errorf("images = %q, want %q", result.Images, want)
+			}
+			if result.MainImage != want[0] || result.ThumbnailURL != want[0] {
+				t.Errorf("main image %q, thumbnail %q, want %q", result.MainImage, result.ThumbnailURL, want[0])
+			}
+		})
+	}
+}
+
+func TestPhaseTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		parent   time.Duration // Zero for no deadline
+		budget   time.Duration
+		wantLeft bool // Whether the phase gets the parent's leftover rather than its budget
+	}{
+		{"no deadline", 0, BrowserTimeout, false},
+		{"browser phase under a 5s deadline", 5 * time.Second, BrowserTimeout, true},
+		{"http phase under a 5s deadline", 5 * time.Second, HTTPTimeout, true},
+		{"deadline beyond the budget", 2 * time.Minute, HTTPTimeout, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.parent > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.parent)
+				defer cancel()
+			}
+
+			got := phaseTimeout(ctx, tt.budget)
+			if !tt.wantLeft {
+				if got != tt.budget {
+					t.Errorf("phaseTimeout = %v, want the %v budget", got, tt.budget)
+				}
+				return
+			}
+			if got > tt.parent || got < tt.parent-time.Second {
+				t.Errorf("phaseTimeout = %v, want the leftover of %v", got, tt.parent)
+			}
+		})
+	}
+}
+
+func TestDetectedCharset(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{"utf-8 source", "text/html; charset=utf-8", articleHTML("Café Reopens"), "utf-8"},
+		{"latin1 source", "text/html; charset=ISO-8859-1", strings.ReplaceAll(articleHTML("Café Reopens"), "é", "\xe9"), "windows-1252"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL, DefaultExtractionOptions())
+			if err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+			if result.Metadata.DetectedCharset != tt.want {
+				t.Errorf("DetectedCharset = %q, want %q", result.Metadata.DetectedCharset, tt.want)
+			}
+			if result.Title != "Café Reopens" {
+				t.Errorf("title = %q", result.Title)
+			}
+		})
+	}
+}
+
+func TestScrapeLoginRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/news/story", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/signin?return=/news/story", http.StatusFound)
+	})
+	mux.HandleFunc("/signin", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, articleHTML("Sign in to continue reading"))
+	})
+	mux.HandleFunc("/news/open", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, articleHTML("Open Story"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		wantLogin bool
+	}{
+		{"redirected to a login page", "/news/story", true},
+		{"article served", "/news/open", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL+tt.path, DefaultExtractionOptions())
+			var loginErr *models.LoginRequiredError
+			if got := errors.As(err, &loginErr); got != tt.wantLogin {
+				t.Fatalf("scrape error = %v, want login required %v", err, tt.wantLogin)
+			}
+			if tt.wantLogin && !strings.Contains(loginErr.URL, "/signin") {
+				t.Errorf("login URL = %q", loginErr.URL)
+			}
+		})
+	}
+}
+
+func TestHTTPSTarget(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceMode  string
+		mode         string
+		target       string
+		wantURL      string
+		wantFallback string
+		wantErr      error
+	}{
+		{"allow", HTTPSModeAllow, "", "http://example.com/a", "http://example.com/a", "", nil},
+		{"upgrade", HTTPSModeAllow, HTTPSModeUpgrade, "http://example.com/a?b=1", "https://example.com/a?b=1", "http://example.com/a?b=1", nil},
+		{"require", HTTPSModeAllow, HTTPSModeRequire, "http://example.com/a", "", "", ErrHTTPSRequired},
+		{"service mode when unset", HTTPSModeRequire, "", "http://example.com/a", "", "", ErrHTTPSRequired},
+		{"request mode over the service's", HTTPSModeRequire, HTTPSModeAllow, "http://example.com/a", "http://example.com/a", "", nil},
+		{"https untouched", HTTPSModeRequire, "", "https://example.com/a", "https://example.com/a", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScraper()
+			s.httpsMode = tt.serviceMode
+			options := DefaultExtractionOptions()
+			options.HTTPSMode = tt.mode
+
+			got, fallback, err := s.httpsTarget(tt.target, options)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("httpsTarget error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.wantURL || fallback != tt.wantFallback {
+				t.Errorf("httpsTarget = %q, %q, want %q, %q", got, fallback, tt.wantURL, tt.wantFallback)
+			}
+		})
+	}
+}
+
+func TestScrapeHTTPSModes(t *testing.T) {
+	server := articleServer("Plain HTTP", nil)
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr error
+	}{
+		{"allow", HTTPSModeAllow, nil},
+		{"upgrade falls back to http", HTTPSModeUpgrade, nil},
+		{"require rejects http", HTTPSModeRequire, ErrHTTPSRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.HTTPSMode = tt.mode
+			result, err := newTestScraper().ScrapeSmartWithOptions(context.Background(), server.URL+"/news", options)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("scrape error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && result.Title != "Plain HTTP" {
+				t.Errorf("title = %q", result.Title)
+			}
+		})
+	}
+}
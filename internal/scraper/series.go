@@ -0,0 +1,46 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FindSeriesLinks returns the absolute URLs of the next and previous posts, from
+// rel="next"/rel="prev" links or the labeled links of a post navigation block
+func FindSeriesLinks(doc *goquery.Document, baseURL string) (next, prev string) {
+	baseURL = ResolveBaseURL(doc, baseURL)
+	nav := doc.Find(SeriesNavSelectors)
+
+	next = firstSeriesLink(baseURL,
+		doc.Find(`link[rel~="next"], a[rel~="next"]`),
+		nav.Find(".nav-next a[href], a.next[href]"))
+	prev = firstSeriesLink(baseURL,
+		doc.Find(`link[rel~="prev"], link[rel~="previous"], a[rel~="prev"], a[rel~="previous"]`),
+		nav.Find(".nav-previous a[href], a.prev[href], a.previous[href]"))
+	return next, prev
+}
+
+// firstSeriesLink resolves the first usable href among the candidate links, in order,
+// skipping links back to the page itself
+func firstSeriesLink(baseURL string, candidates ...*goquery.Selection) string {
+	for _, links := range candidates {
+		var link string
+		links.EachWithBreak(func(i int, s *goquery.Selection) bool {
+			href := strings.TrimSpace(s.AttrOr("href", ""))
+			if href == "" || strings.HasPrefix(href, "#") {
+				return true
+			}
+			absURL, err := ResolveURL(href, baseURL)
+			if err != nil || !strings.HasPrefix(absURL, "http") || absURL == baseURL {
+				return true
+			}
+			link = absURL
+			return false
+		})
+		if link != "" {
+			return link
+		}
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package scraper
+
+import "testing"
+
+func TestFindSeriesLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantNext string
+		wantPrev string
+	}{
+		{
+			name:     "rel link tags",
+			html:     `<html><head><link rel="next" href="/blog/part-3"><link rel="prev" href="https://example.com/blog/part-1"></head><body></body></html>`,
+			wantNext: "https://example.com/blog/part-3",
+			wantPrev: "https://example.com/blog/part-1",
+		},
+		{
+			name:     "rel previous anchors",
+			html:     `<html><body><a rel="previous" href="part-1">Older</a><a rel="next" href="part-3">Newer</a></body></html>`,
+			wantNext: "https://example.com/blog/part-3",
+			wantPrev: "https://example.com/blog/part-1",
+		},
+		{
+			name:     "post navigation block",
+			html:     `<html><body><nav class="post-navigation"><div class="nav-previous"><a href="/blog/part-1">Part 1</a></div><div class="nav-next"><a href="/blog/part-3">Part 3</a></div></nav></body></html>`,
+			wantNext: "https://example.com/blog/part-3",
+			wantPrev: "https://example.com/blog/part-1",
+		},
+		{
+			name: "self and fragment links skipped",
+			html: `<html><head><link rel="next" href="https://example.com/blog/part-2"><link rel="prev" href="#top"></head><body></body></html>`,
+		},
+		{
+			name: "standalone post",
+			html: `<html><body><p>No series here.</p></body></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, prev := FindSeriesLinks(parseDoc(t, tt.html), "https://example.com/blog/part-2")
+			if next != tt.wantNext || prev != tt.wantPrev {
+				t.Errorf("next %q, prev %q, want %q, %q", next, prev, tt.wantNext, tt.wantPrev)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package scraper
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// LooksLikeSoft404 checks if an HTTP 200 page is really a "page not found" page, as
+// guessed AMP/mobile alternates often are: the title or first heading says so, or a
+// near-empty body does
+func LooksLikeSoft404(html string, pattern *regexp.Regexp) bool {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+
+	for _, heading := range []string{doc.Find("title").First().Text(), doc.Find("h1").First().Text()} {
+		if pattern.MatchString(heading) {
+			return true
+		}
+	}
+
+	body := doc.Find("body").Clone()
+	body.Find(NonContentTags).Remove()
+	text := body.Text()
+	return len(strings.Fields(text)) <= Soft404MaxWords && pattern.MatchString(text)
+}
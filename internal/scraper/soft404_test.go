@@ -0,0 +1,78 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// notFoundPage is a site's "page not found" page served with HTTP 200
+const notFoundPage = `<html><head><title>Page Not Found | Example News</title></head><body><h1>Oops!</h1><p>We couldn't find that page.</p></body></html>`
+
+func TestLooksLikeSoft404(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{"not found title", notFoundPage, true},
+		{"not found heading", `<html><head><title>Example News</title></head><body><h1>404 - Page not found</h1></body></html>`, true},
+		{"near-empty body", `<html><head><title>Example News</title></head><body><nav>Home News Sports</nav><p>Sorry, this page does not exist.</p></body></html>`, true},
+		{"long body mentioning a missing page", strings.Replace(multiSectionPage, "</article>", "<p>Old links may show page not found.</p></article>", 1), false},
+		{"article", articleHTML("Transit Plan Approved"), false},
+	}
+
+	client := NewHTTPClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.LooksLikeSoft404(tt.html); got != tt.want {
+				t.Errorf("LooksLikeSoft404() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlternatesSkipSoft404(t *testing.T) {
+	tests := []struct {
+		name      string
+		ampSuffix bool // Whether /story/amp serves the article or a soft 404 too
+		wantErr   bool
+	}{
+		{"real alternate wins", true, false},
+		{"only soft 404s", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				switch {
+				case r.URL.Path == "/story":
+					w.WriteHeader(http.StatusForbidden)
+				case r.URL.Path == "/story/amp" && tt.ampSuffix:
+					fmt.Fprint(w, articleHTML("Transit Plan Approved"))
+				default:
+					fmt.Fprint(w, notFoundPage)
+				}
+			}))
+			defer server.Close()
+
+			result, err := NewHTTPClient().FetchWithAlternatesOptions(context.Background(), server.URL+"/story", FetchOptions{})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("fetch returned %s, want an error", result.URL)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if result.URL != server.URL+"/story/amp" || !strings.Contains(result.HTML, "Transit Plan Approved") {
+				t.Errorf("result = %s, want the real AMP alternate", result.URL)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package scraper
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SrcsetCandidate is one srcset entry. Width is set by a "w" descriptor and Density by
+// an "x" descriptor; an entry without either has density 1.
+type SrcsetCandidate struct {
+	URL     string
+	Width   int
+	Density float64
+}
+
+// ParseSrcset splits a srcset attribute into its candidates following the HTML
+// parsing rules: a URL runs to the next whitespace, so commas inside it (data: URIs,
+// Cloudinary "w_400,h_300" steps) are kept, and its descriptors run to the next comma
+// outside parentheses. Whitespace, newlines included, may appear anywhere between
+// tokens. Entries with invalid descriptors are dropped.
+func ParseSrcset(srcset string) []SrcsetCandidate {
+	var candidates []SrcsetCandidate
+	rest := srcset
+
+	for {
+		rest = strings.TrimLeftFunc(rest, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+		if rest == "" {
+			return candidates
+		}
+
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		rawURL := rest[:end]
+		rest = rest[end:]
+
+		// A URL ending in commas has no descriptors
+		var descriptors string
+		if trimmed := strings.TrimRight(rawURL, ","); trimmed != rawURL {
+			rawURL = trimmed
+		} else {
+			descriptors, rest = cutSrcsetDescriptors(rest)
+		}
+
+		if candidate, ok := parseSrcsetDescriptors(rawURL, descriptors); ok && rawURL != "" {
+			candidates = append(candidates, candidate)
+		}
+	}
+}
+
+// cutSrcsetDescriptors returns the descriptors up to the next comma outside
+// parentheses, and what follows that comma
+func cutSrcsetDescriptors(s string) (string, string) {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				return s[:i], s[i+1:]
+			}
+		}
+	}
+	return s, ""
+}
+
+// parseSrcsetDescriptors reads an entry's "w", "x" and "h" descriptors; "h" is valid
+// but unused
+func parseSrcsetDescriptors(rawURL, descriptors string) (SrcsetCandidate, bool) {
+	candidate := SrcsetCandidate{URL: rawURL}
+
+	for _, descriptor := range strings.Fields(strings.ToLower(descriptors)) {
+		if len(descriptor) < 2 {
+			return candidate, false
+		}
+		value := descriptor[:len(descriptor)-1]
+
+		switch descriptor[len(descriptor)-1] {
+		case 'w':
+			width, err := strconv.Atoi(value)
+			if err != nil || width <= 0 || candidate.Width != 0 || candidate.Density != 0 {
+				return candidate, false
+			}
+			candidate.Width = width
+		case 'x':
+			density, err := strconv.ParseFloat(value, 64)
+			if err != nil || density <= 0 || candidate.Width != 0 || candidate.Density != 0 {
+				return candidate, false
+			}
+			candidate.Density = density
+		case 'h':
+			if _, err := strconv.Atoi(value); err != nil {
+				return candidate, false
+			}
+		default:
+			return candidate, false
+		}
+	}
+
+	if candidate.Width == 0 && candidate.Density == 0 {
+		candidate.Density = 1
+	}
+	return candidate, true
+}
@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSrcset(t *testing.T) {
+	tests := []struct {
+		name   string
+		srcset string
+		want   []SrcsetCandidate
+	}{
+		{
+			name: "multi-line with extra spaces",
+			srcset: `
+				/img/small.jpg    480w,
+				/img/medium.jpg
+					800w ,
+				/img/large.jpg	1200w
+			`,
+			want: []SrcsetCandidate{
+				{URL: "/img/small.jpg", Width: 480},
+				{URL: "/img/medium.jpg", Width: 800},
+				{URL: "/img/large.jpg", Width: 1200},
+			},
+		},
+		{
+			name:   "data uri with commas",
+			srcset: "data:image/gif;base64,R0lGODlhAQABAAAAACw= 1w, /img/real.jpg 1000w",
+			want: []SrcsetCandidate{
+				{URL: "data:image/gif;base64,R0lGODlhAQABAAAAACw=", Width: 1},
+				{URL: "/img/real.jpg", Width: 1000},
+			},
+		},
+		{
+			name:   "cloudinary steps in the url",
+			srcset: "https://res.cloudinary.com/demo/image/upload/w_400,h_300/a.jpg 400w",
+			want:   []SrcsetCandidate{{URL: "https://res.cloudinary.com/demo/image/upload/w_400,h_300/a.jpg", Width: 400}},
+		},
+		{
+			name:   "densities and a bare url",
+			srcset: "/img/a.jpg, /img/a@2x.jpg 2x,/img/a@1.5x.jpg 1.5x",
+			want: []SrcsetCandidate{
+				{URL: "/img/a.jpg", Density: 1},
+				{URL: "/img/a@2x.jpg", Density: 2},
+				{URL: "/img/a@1.5x.jpg", Density: 1.5},
+			},
+		},
+		{
+			name:   "invalid descriptors dropped",
+			srcset: "/img/a.jpg 400q, /img/b.jpg 400w 2x, /img/c.jpg 640w 360h",
+			want:   []SrcsetCandidate{{URL: "/img/c.jpg", Width: 640}},
+		},
+		{
+			name:   "empty",
+			srcset: " , ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSrcset(tt.srcset); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSrcset = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickFromSrcset(t *testing.T) {
+	tests := []struct {
+		name   string
+		srcset string
+		want   string
+	}{
+		{"closest to the target width", "/a-480.jpg 480w,\n/a-960.jpg 960w,\n/a-1920.jpg 1920w", "/a-960.jpg"},
+		{"data uri placeholder skipped", "data:image/gif;base64,R0lGOD,lhAQ= 1000w, /a-1200.jpg 1200w", "/a-1200.jpg"},
+		{"highest density", "/a.jpg 1x, /a@3x.jpg 3x, /a@2x.jpg 2x", "/a@3x.jpg"},
+		{"only placeholders", "data:image/gif;base64,R0lGOD 1x", ""},
+	}
+
+	ie := NewImageExtractor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ie.pickFromSrcset(tt.srcset); got != tt.want {
+				t.Errorf("pickFromSrcset = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractTemplateContent parses the markup of each template matched by TemplateSelectors
+// as a fragment and returns the text of the one with the most words, at least
+// TemplateMinWords, for web-component pages whose article only sits in templates
+func ExtractTemplateContent(doc *goquery.Document) string {
+	var best string
+	bestWords := 0
+
+	doc.Find(TemplateSelectors).Each(func(i int, s *goquery.Selection) {
+		// Script templates hold their markup as raw text
+		markup := s.Text()
+		if goquery.NodeName(s) == "template" {
+			markup, _ = s.Html()
+		}
+
+		fragment, err := goquery.NewDocumentFromReader(strings.NewReader(markup))
+		if err != nil {
+			return
+		}
+		fragment.Find(NonContentTags).Remove()
+
+		text := ExtractTextFromElements(fragment.Selection, TextElements, false)
+		if text == "" {
+			text = ExtractFallbackText(fragment.Selection)
+		}
+		if words := len(strings.Fields(text)); words >= TemplateMinWords && words > bestWords {
+			best, bestWords = text, words
+		}
+	})
+
+	return CleanTextContent(best)
+}
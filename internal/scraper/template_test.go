@@ -0,0 +1,85 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+// templateBody is a 60-word article body, long enough to pass TemplateMinWords
+var templateBody = strings.Repeat("The harbour reopened to ferries after the storm damage was repaired. ", 6)
+
+// componentPage is a web-component page whose article only sits in inert markup
+func componentPage(inert string) string {
+	return `<html><head><title>Harbour Reopens</title></head><body><news-article></news-article>` + inert + `</body></html>`
+}
+
+func TestExtractTemplateContent(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "template element",
+			html: componentPage(`<template id="article"><article><p>` + templateBody + `</p></article></template>`),
+			want: strings.TrimSpace(templateBody),
+		},
+		{
+			name: "script template",
+			html: componentPage(`<script type="text/template"><div><p>` + templateBody + `</p></div></script>`),
+			want: strings.TrimSpace(templateBody),
+		},
+		{
+			name: "longest template wins",
+			html: componentPage(`<template><p>` + templateBody + `</p></template><template><p>` + templateBody + templateBody + `</p></template>`),
+			want: strings.TrimSpace(templateBody + templateBody),
+		},
+		{
+			name: "ui template too short",
+			html: componentPage(`<template><button>Share this story</button></template>`),
+		},
+		{
+			name: "declarative shadow root skipped",
+			html: componentPage(`<div><template shadowrootmode="open"><p>` + templateBody + `</p></template></div>`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractTemplateContent(parseDoc(t, tt.html)); got != tt.want {
+				t.Errorf("ExtractTemplateContent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseTemplates(t *testing.T) {
+	page := componentPage(`<script type="text/template" id="article"><article><p>` + templateBody + `</p></article></script>`)
+
+	tests := []struct {
+		name         string
+		useTemplates bool
+		wantBody     bool
+	}{
+		{"sparse page read from template", true, true},
+		{"off", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.UseTemplates = tt.useTemplates
+			options.IncludeDiagnostics = true
+			result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/harbour", options)
+			if err != nil {
+				t.Fatalf("extract: %v", err)
+			}
+			if got := strings.Contains(result.Content, "harbour reopened"); got != tt.wantBody {
+				t.Errorf("body in content = %v, want %v: %q", got, tt.wantBody, result.Content)
+			}
+			if method := result.Diagnostics.ExtractionMethod; tt.wantBody && method != ExtractionMethodTemplate {
+				t.Errorf("ExtractionMethod = %q, want %q", method, ExtractionMethodTemplate)
+			}
+		})
+	}
+}
@@ -2,7 +2,13 @@
 package scraper
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
 	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // CleanWhitespace removes excessive whitespace from text content
@@ -11,6 +17,9 @@ func CleanWhitespace(text string) string {
 		return ""
 	}
 
+	// Drop injected invisible characters and settle on one Unicode form
+	text = norm.NFC.String(StripInvisibleChars(text))
+
 	// Remove excessive whitespace
 	cleaned := strings.ReplaceAll(text, TripleNewline, DoubleNewline)
 	cleaned = strings.ReplaceAll(cleaned, DoubleSpace, SingleSpace)
@@ -19,8 +28,91 @@ func CleanWhitespace(text string) string {
 	return cleaned
 }
 
+// StripInvisibleChars removes zero-width characters, soft hyphens and bidi controls that
+// sites inject to break scrapers. A zero-width joiner after a symbol is kept, since it
+// glues multi-part emoji together.
+func StripInvisibleChars(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	var prev rune
+	for _, r := range text {
+		switch {
+		case r == '\u200D':
+			if !isEmojiPart(prev) {
+				continue
+			}
+		case isInvisibleChar(r):
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+// isInvisibleChar reports zero-width spaces, soft hyphens, word joiners and bidi controls
+func isInvisibleChar(r rune) bool {
+	switch {
+	case r == '\u200B', r == '\u200C', r == '\uFEFF', r == '\u00AD', r == '\u2060':
+		return true
+	case r == '\u061C', r == '\u200E', r == '\u200F':
+		return true
+	case r >= '\u202A' && r <= '\u202E', r >= '\u2066' && r <= '\u2069':
+		return true
+	}
+	return false
+}
+
+// isEmojiPart reports runes that can precede a zero-width joiner in an emoji sequence:
+// pictographs, variation selectors and skin tone modifiers
+func isEmojiPart(r rune) bool {
+	return unicode.Is(unicode.So, r) || r == '\uFE0F' || (r >= 0x1F3FB && r <= 0x1F3FF)
+}
+
+// Slugify turns a title into a filename- and URL-safe slug: accents folded, lowercased,
+// emoji and punctuation dropped, words joined by hyphens and cut at a word to SlugMaxLength
+func Slugify(title string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range norm.NFD.String(title) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining accents left by the decomposition
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(unicode.ToLower(r))
+		case r == '\'' || r == '\u2019':
+			// Keep contractions such as "don't" as one word
+			continue
+		default:
+			pendingHyphen = true
+		}
+	}
+
+	slug := norm.NFC.String(b.String())
+	if len(slug) <= SlugMaxLength {
+		return slug
+	}
+	slug = slug[:SlugMaxLength]
+	if idx := strings.LastIndexByte(slug, '-'); idx > 0 {
+		return slug[:idx]
+	}
+	return strings.ToValidUTF8(slug, "")
+}
+
 // CleanTextContent removes common noise patterns from text content
 func CleanTextContent(text string) string {
+	return cleanTextLines(text, nil)
+}
+
+// cleanTextLines is CleanTextContent also keeping the short lines keep accepts
+func cleanTextLines(text string, keep func(line string) bool) string {
 	if text == "" {
 		return ""
 	}
@@ -31,8 +123,8 @@ func CleanTextContent(text string) string {
 
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
-		// Keep lines that are longer than 20 characters or are empty (for spacing)
-		if len(line) == 0 || len(line) > 20 {
+		// Keep lines that are longer than MinContentLineLength or are empty (for spacing)
+		if len(line) == 0 || len(line) > MinContentLineLength || (keep != nil && keep(line)) {
 			cleanedLines = append(cleanedLines, line)
 		}
 	}
@@ -71,6 +163,172 @@ func CalculateContentMetrics(content string) (wordCount, paragraphCount, avgPara
 	return wordCount, paragraphCount, avgParagraphLength
 }
 
+// HashContent returns the hex SHA-256 of the text with whitespace normalized,
+// so cosmetic reflows don't change the hash. Empty text hashes to "".
+func HashContent(text string) string {
+	normalized := strings.Join(strings.Fields(text), " ")
+	if normalized == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// EstimateReadingTime returns the reading time in minutes (minimum 1 for non-empty text).
+// CJK text is measured in characters per minute, everything else in words per minute.
+func EstimateReadingTime(text, language string, wordsPerMinute, charsPerMinute int) int {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0
+	}
+
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	if charsPerMinute <= 0 {
+		charsPerMinute = DefaultCharsPerMinute
+	}
+
+	var minutes float64
+	if IsCJKLanguage(language) || (language == "" && isMostlyCJK(text)) {
+		chars := 0
+		for _, r := range text {
+			if !unicode.IsSpace(r) && !unicode.IsPunct(r) {
+				chars++
+			}
+		}
+		minutes = float64(chars) / float64(charsPerMinute)
+	} else {
+		minutes = float64(len(strings.Fields(text))) / float64(wordsPerMinute)
+	}
+
+	readingTime := int(math.Round(minutes))
+	if readingTime < 1 {
+		readingTime = 1
+	}
+	return readingTime
+}
+
+// IsCJKLanguage checks if a language code is Chinese, Japanese or Korean
+func IsCJKLanguage(language string) bool {
+	language = strings.ToLower(strings.TrimSpace(language))
+	for _, prefix := range []string{"zh", "ja", "ko"} {
+		if language == prefix || strings.HasPrefix(language, prefix+"-") || strings.HasPrefix(language, prefix+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// isMostlyCJK checks if most letters in the text are CJK ideographs, kana or hangul
+func isMostlyCJK(text string) bool {
+	letters, cjk := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+		}
+	}
+	return letters > 0 && cjk*2 > letters
+}
+
+// TruncateText shortens text to at most maxLen characters, cutting after the last sentence
+// that fits, or at a word boundary when no sentence does. It reports whether text was cut.
+func TruncateText(text string, maxLen int) (string, bool) {
+	runes := []rune(text)
+	if maxLen <= 0 || len(runes) <= maxLen {
+		return text, false
+	}
+
+	cut := string(runes[:maxLen])
+
+	// Latin sentence ends must be followed by whitespace, so "3.5" doesn't count;
+	// CJK full stops need no space after them
+	sentenceEnd := 0
+	for i := 0; i < maxLen; i++ {
+		switch runes[i] {
+		case '。', '！', '？':
+			sentenceEnd = i + 1
+		case '.', '!', '?':
+			if i+1 < len(runes) && unicode.IsSpace(runes[i+1]) {
+				sentenceEnd = i + 1
+			}
+		}
+	}
+	if sentenceEnd > 0 {
+		return strings.TrimSpace(string(runes[:sentenceEnd])), true
+	}
+
+	if wordEnd := strings.LastIndexFunc(cut, unicode.IsSpace); wordEnd > 0 {
+		return strings.TrimSpace(cut[:wordEnd]), true
+	}
+	return cut, true
+}
+
+// ChunkText splits text into chunks of at most size characters, breaking between
+// paragraphs (lines). A paragraph longer than size is cut like TruncateText, at the
+// last sentence or word boundary that fits, and its pieces start new chunks.
+func ChunkText(text string, size int) []string {
+	if size <= 0 || strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	var chunks []string
+	var current []string
+	currentLen := 0
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, SingleNewline))
+		}
+		current, currentLen = nil, 0
+	}
+
+	for _, paragraph := range strings.Split(text, SingleNewline) {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		length := len([]rune(paragraph))
+		if length > size {
+			flush()
+			chunks = append(chunks, splitParagraph(paragraph, size)...)
+			continue
+		}
+
+		// Paragraphs are joined by a newline, which counts towards the size
+		if len(current) > 0 && currentLen+1+length > size {
+			flush()
+		}
+		if len(current) > 0 {
+			currentLen++
+		}
+		current = append(current, paragraph)
+		currentLen += length
+	}
+	flush()
+
+	return chunks
+}
+
+// splitParagraph cuts a paragraph into pieces of at most size characters
+func splitParagraph(paragraph string, size int) []string {
+	var pieces []string
+	for paragraph != "" {
+		piece, cut := TruncateText(paragraph, size)
+		pieces = append(pieces, piece)
+		if !cut {
+			break
+		}
+		paragraph = strings.TrimSpace(string([]rune(paragraph)[len([]rune(piece)):]))
+	}
+	return pieces
+}
+
 // ContainsAny checks if a string contains any of the substrings (case-insensitive)
 func ContainsAny(s string, substrings []string) bool {
 	sLower := strings.ToLower(s)
@@ -0,0 +1,220 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"extract-html-scraper/internal/models"
+)
+
+func TestEstimateReadingTime(t *testing.T) {
+	english := strings.Repeat("word ", 600)
+	japanese := strings.Repeat("日本語の文章です。", 200) // 8 counted characters per sentence
+
+	tests := []struct {
+		name           string
+		text           string
+		language       string
+		wordsPerMinute int
+		charsPerMinute int
+		want           int
+	}{
+		{"english default speed", english, "en", 0, 0, 3},
+		{"english custom speed", english, "en", 300, 0, 2},
+		{"japanese by characters", japanese, "ja", 0, 0, 3},
+		{"japanese custom speed", japanese, "ja-JP", 0, 400, 4},
+		{"japanese detected without language", japanese, "", 0, 0, 3},
+		{"short text is one minute", "a few words", "en", 0, 0, 1},
+		{"empty", "  ", "en", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateReadingTime(tt.text, tt.language, tt.wordsPerMinute, tt.charsPerMinute); got != tt.want {
+				t.Errorf("EstimateReadingTime() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCJKLanguage(t *testing.T) {
+	tests := map[string]bool{"ja": true, "zh-Hant": true, "ko_KR": true, "en": false, "jav": false, "": false}
+	for language, want := range tests {
+		if got := IsCJKLanguage(language); got != want {
+			t.Errorf("IsCJKLanguage(%q) = %v, want %v", language, got, want)
+		}
+	}
+}
+
+func TestStripInvisibleChars(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"zero-width space", "Bud\u200Bget\u200B Vote", "Budget Vote"},
+		{"soft hyphen and word joiner", "trans\u00ADit\u2060 plan", "transit plan"},
+		{"bidi controls", "\u202Eplan\u202C \u200Fvote", "plan vote"},
+		{"byte order mark", "\uFEFFHeadline", "Headline"},
+		{"emoji joiner kept", "family \U0001F468\u200D\U0001F469\u200D\U0001F467", "family \U0001F468\u200D\U0001F469\u200D\U0001F467"},
+		{"joiner between letters dropped", "a\u200Db", "ab"},
+		{"plain text unchanged", "Transit plan approved", "Transit plan approved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripInvisibleChars(tt.text); got != tt.want {
+				t.Errorf("StripInvisibleChars(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleInvisibleChars(t *testing.T) {
+	obfuscated := "Tran\u200Bsit Pl\u200Dan\u2060 Approved"
+	page := strings.ReplaceAll(multiSectionPage, "Transit Plan Approved", obfuscated)
+
+	result, err := NewArticleExtractor().ExtractArticleWithOptions(page, "https://example.com/news/transit", DefaultExtractionOptions())
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+	if result.Title != "Transit Plan Approved" {
+		t.Errorf("title = %q, want the invisible characters stripped", result.Title)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxLen   int
+		want     string
+		wantTrim bool
+	}{
+		{"fits", "Short text.", 50, "Short text.", false},
+		{"no limit", "Short text.", 0, "Short text.", false},
+		{"last full sentence", "First sentence here. Second one is longer than the limit.", 30, "First sentence here.", true},
+		{"decimal is not a sentence end", "Rates rose 3.5 percent in the quarter", 20, "Rates rose 3.5", true},
+		{"word boundary without a sentence", "One long sentence without any stop at all", 20, "One long sentence", true},
+		{"cjk full stop", "第一句。第二句很长很长。", 6, "第一句。", true},
+		{"single long word", "Supercalifragilistic", 5, "Super", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := TruncateText(tt.text, tt.maxLen)
+			if got != tt.want || truncated != tt.wantTrim {
+				t.Errorf("TruncateText(%q, %d) = %q, %v, want %q, %v", tt.text, tt.maxLen, got, truncated, tt.want, tt.wantTrim)
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"plain title", "Transit Plan Approved", "transit-plan-approved"},
+		{"accents folded", "Café Crème à Paris", "cafe-creme-a-paris"},
+		{"punctuation and emoji dropped", "Breaking: Storm Hits Coast! 🌊", "breaking-storm-hits-coast"},
+		{"contractions kept together", "Don't Panic, It’s Fine", "dont-panic-its-fine"},
+		{"non-latin letters kept", "東京 Olympics 2020", "東京-olympics-2020"},
+		{"cut at a word", strings.Repeat("word ", 30), strings.TrimSuffix(strings.Repeat("word-", SlugMaxLength/5), "-")},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.title)
+			if got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+			if len(got) > SlugMaxLength {
+				t.Errorf("slug is %d bytes, over SlugMaxLength", len(got))
+			}
+		})
+	}
+}
+
+func TestChunkText(t *testing.T) {
+	const text = "First paragraph here.\nSecond paragraph is longer.\n\nThird one."
+
+	tests := []struct {
+		name string
+		text string
+		size int
+		want []string
+	}{
+		{"disabled", text, 0, nil},
+		{"empty", " \n ", 100, nil},
+		{"fits in one chunk", text, 100, []string{"First paragraph here.\nSecond paragraph is longer.\nThird one."}},
+		{"split at paragraph breaks", text, 50, []string{"First paragraph here.\nSecond paragraph is longer.", "Third one."}},
+		{"one paragraph per chunk", text, 30, []string{"First paragraph here.", "Second paragraph is longer.", "Third one."}},
+		{"oversized paragraph cut at sentences", "One sentence here. Another sentence there.\nTail.", 25, []string{"One sentence here.", "Another sentence there.", "Tail."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ChunkText(tt.text, tt.size)
+			if !equalStrings(got, tt.want) {
+				t.Fatalf("ChunkText(%d) = %q, want %q", tt.size, got, tt.want)
+			}
+			for _, chunk := range got {
+				if n := len([]rune(chunk)); n > tt.size {
+					t.Errorf("chunk %q is %d characters, over %d", chunk, n, tt.size)
+				}
+			}
+		})
+	}
+}
+
+func TestHashContent(t *testing.T) {
+	base := HashContent("The council approved the plan.\n\nBus lanes open next spring.")
+
+	tests := []struct {
+		name     string
+		text     string
+		wantSame bool
+	}{
+		{"identical", "The council approved the plan.\n\nBus lanes open next spring.", true},
+		{"reflowed whitespace", "  The council  approved the plan.\n\n\n\tBus lanes open\nnext spring.  ", true},
+		{"changed word", "The council rejected the plan.\n\nBus lanes open next spring.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HashContent(tt.text) == base; got != tt.wantSame {
+				t.Errorf("same hash = %v, want %v", got, tt.wantSame)
+			}
+		})
+	}
+
+	if HashContent(" \n ") != "" {
+		t.Error("blank text should hash to empty")
+	}
+	if len(base) != 64 {
+		t.Errorf("hash %q is not hex SHA-256", base)
+	}
+}
+
+func TestSetContentHashes(t *testing.T) {
+	a := articleResponse("Title", "Body text here.")
+	b := articleResponse("Title", "  Body   text\nhere. ")
+	c := articleResponse("Other title", "Body text here.")
+
+	if a.ContentHash != b.ContentHash || a.TitleContentHash != b.TitleContentHash {
+		t.Error("whitespace changed the hashes")
+	}
+	if a.ContentHash != c.ContentHash || a.TitleContentHash == c.TitleContentHash {
+		t.Error("a title change should only change TitleContentHash")
+	}
+}
+
+// articleResponse returns a response with the change-detection hashes set
+func articleResponse(title, content string) models.ScrapeResponse {
+	response := models.ScrapeResponse{Title: title, Content: content}
+	setContentHashes(&response)
+	return response
+}
@@ -0,0 +1,61 @@
+package scraper
+
+import "testing"
+
+func TestThumbnailURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "wordpress upload",
+			url:  "https://blog.example.com/wp-content/uploads/2024/03/transit.jpg",
+			want: "https://blog.example.com/wp-content/uploads/2024/03/transit-150x150.jpg",
+		},
+		{
+			name: "wordpress size variant replaced",
+			url:  "https://blog.example.com/wp-content/uploads/2024/03/transit-1024x683.jpg",
+			want: "https://blog.example.com/wp-content/uploads/2024/03/transit-150x150.jpg",
+		},
+		{
+			name: "imgix",
+			url:  "https://news.imgix.net/photos/transit.jpg",
+			want: "https://news.imgix.net/photos/transit.jpg?w=200",
+		},
+		{
+			name: "imgix height dropped",
+			url:  "https://news.imgix.net/photos/transit.jpg?h=900&w=1600",
+			want: "https://news.imgix.net/photos/transit.jpg?w=200",
+		},
+		{
+			name: "cloudinary after existing crop",
+			url:  "https://res.cloudinary.com/demo/image/upload/c_fill,h_600/v1700000000/transit.jpg",
+			want: "https://res.cloudinary.com/demo/image/upload/c_fill,h_600/w_200,c_limit/v1700000000/transit.jpg",
+		},
+		{
+			name: "shopify",
+			url:  "https://cdn.shopify.com/s/files/1/transit.jpg",
+			want: "https://cdn.shopify.com/s/files/1/transit.jpg?width=200",
+		},
+		{
+			name: "width param on another host lowered",
+			url:  "https://images.example.com/transit.jpg?width=1600",
+			want: "https://images.example.com/transit.jpg?width=200",
+		},
+		{
+			name: "unrecognized cdn unchanged",
+			url:  "https://static.example.com/images/transit.jpg",
+			want: "https://static.example.com/images/transit.jpg",
+		},
+	}
+
+	ie := NewImageExtractor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ie.ThumbnailURL(tt.url); got != tt.want {
+				t.Errorf("ThumbnailURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package scraper
+
+import (
+	"strings"
+
+	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractTOC returns the entries of the page's table of contents: the first .toc-like
+// block or nav[role] whose links mostly jump within the page. In-page links keep their
+// "#fragment" as Anchor; links leaving the page are resolved to absolute URLs and
+// marked External. Returns nil when the page has no table of contents.
+func ExtractTOC(doc *goquery.Document, baseURL string) []models.TOCEntry {
+	baseURL = ResolveBaseURL(doc, baseURL)
+	pageURL := strings.SplitN(baseURL, "#", 2)[0]
+
+	var entries []models.TOCEntry
+	doc.Find(TOCSelectors).EachWithBreak(func(i int, container *goquery.Selection) bool {
+		entries = tocEntries(container, pageURL)
+
+		inPage := 0
+		for _, entry := range entries {
+			if !entry.External {
+				inPage++
+			}
+		}
+		if inPage >= TOCMinAnchors && inPage*2 >= len(entries) {
+			return false
+		}
+		entries = nil
+		return true
+	})
+
+	return entries
+}
+
+// tocEntries reads the links of a TOC candidate, skipping empty and repeated ones
+func tocEntries(container *goquery.Selection, pageURL string) []models.TOCEntry {
+	var entries []models.TOCEntry
+	seen := make(map[string]bool)
+
+	container.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href := strings.TrimSpace(s.AttrOr("href", ""))
+		text := CleanWhitespace(strings.Join(strings.Fields(s.Text()), SingleSpace))
+		if text == "" || href == "" || href == "#" || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return
+		}
+
+		entry := models.TOCEntry{Text: text, Anchor: href}
+		if !strings.HasPrefix(href, "#") {
+			absURL, err := ResolveURL(href, pageURL)
+			if err != nil {
+				return
+			}
+			// Links to the page's own URL with a fragment are in-page too
+			if target, fragment, found := strings.Cut(absURL, "#"); found && target == pageURL && fragment != "" {
+				entry.Anchor = "#" + fragment
+			} else {
+				entry.Anchor = absURL
+				entry.External = true
+			}
+		}
+
+		if seen[entry.Anchor] {
+			return
+		}
+		seen[entry.Anchor] = true
+		entries = append(entries, entry)
+	})
+
+	return entries
+}
@@ -0,0 +1,60 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+
+	"extract-html-scraper/internal/models"
+)
+
+func TestExtractTOC(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []models.TOCEntry
+	}{
+		{
+			name: "toc block of three anchors",
+			html: `<div class="toc"><ul><li><a href="#install">Installation</a></li><li><a href="#usage">Usage</a></li><li><a href="#faq">FAQ</a></li></ul></div>`,
+			want: []models.TOCEntry{
+				{Text: "Installation", Anchor: "#install"},
+				{Text: "Usage", Anchor: "#usage"},
+				{Text: "FAQ", Anchor: "#faq"},
+			},
+		},
+		{
+			name: "own url fragments and an external link",
+			html: `<nav role="navigation"><a href="/docs/guide#setup">Setup</a><a href="#api">API</a><a href="https://github.com/example/repo">Source</a></nav>`,
+			want: []models.TOCEntry{
+				{Text: "Setup", Anchor: "#setup"},
+				{Text: "API", Anchor: "#api"},
+				{Text: "Source", Anchor: "https://github.com/example/repo", External: true},
+			},
+		},
+		{
+			name: "repeated and empty links skipped",
+			html: `<div id="toc"><a href="#a">First</a><a href="#a">First again</a><a href="#">Top</a><a href="#b"> </a><a href="#c">Third</a></div>`,
+			want: []models.TOCEntry{
+				{Text: "First", Anchor: "#a"},
+				{Text: "Third", Anchor: "#c"},
+			},
+		},
+		{
+			name: "site navigation is not a toc",
+			html: `<nav role="navigation"><a href="/">Home</a><a href="/blog">Blog</a><a href="/about">About</a><a href="#main">Skip</a></nav>`,
+		},
+		{
+			name: "no toc",
+			html: `<article><p>Just text.</p></article>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractTOC(parseDoc(t, "<html><body>"+tt.html+"</body></html>"), "https://example.com/docs/guide")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractTOC = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
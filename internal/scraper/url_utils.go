@@ -0,0 +1,198 @@
+// Package scraper provides URL normalization utilities for extracted links and images.
+package scraper
+
+import (
+	"net/url"
+	"strings"
+
+	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ResolveBaseURL returns the base for resolving relative URLs in the document,
+// honoring <base href>, then the canonical host when the page was fetched from a
+// mobile or AMP alternate host of the same site, and falling back to the fetch URL
+func ResolveBaseURL(doc *goquery.Document, fetchURL string) string {
+	href, exists := doc.Find("base[href]").First().Attr("href")
+	if !exists || strings.TrimSpace(href) == "" {
+		return canonicalBaseURL(doc, fetchURL)
+	}
+
+	// A relative <base href> is itself resolved against the fetch URL
+	base, err := ResolveURL(href, fetchURL)
+	if err != nil {
+		return fetchURL
+	}
+
+	parsed, err := url.Parse(base)
+	if err != nil || parsed.Host == "" {
+		return fetchURL
+	}
+
+	return base
+}
+
+// canonicalBaseURL moves the fetch URL onto the canonical URL's host when the two are
+// hosts of one site, such as m.example.com serving www.example.com's article, since
+// the alternate host may not serve the images. The path stays the fetched page's, as
+// path-relative URLs are relative to the document actually served.
+func canonicalBaseURL(doc *goquery.Document, fetchURL string) string {
+	href := strings.TrimSpace(doc.Find(`link[rel~="canonical"][href]`).First().AttrOr("href", ""))
+	if href == "" {
+		return fetchURL
+	}
+
+	fetched, err := url.Parse(fetchURL)
+	if err != nil {
+		return fetchURL
+	}
+	canonical, err := url.Parse(href)
+	if err != nil || (canonical.Scheme != "http" && canonical.Scheme != "https") {
+		return fetchURL
+	}
+
+	fetchedHost := strings.ToLower(fetched.Host)
+	canonicalHost := strings.ToLower(canonical.Host)
+	if fetchedHost == canonicalHost || siteHost(fetchedHost) != siteHost(canonicalHost) {
+		return fetchURL
+	}
+
+	fetched.Scheme = canonical.Scheme
+	fetched.Host = canonical.Host
+	return fetched.String()
+}
+
+// siteHost strips the www, mobile and AMP subdomain a site may serve pages from
+func siteHost(host string) string {
+	for _, prefix := range AlternateHostPrefixes {
+		if trimmed := strings.TrimPrefix(host, prefix); trimmed != host && strings.Contains(trimmed, ".") {
+			return trimmed
+		}
+	}
+	return host
+}
+
+// ResolveURL converts a possibly relative URL to absolute against the given base
+func ResolveURL(ref, baseURL string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", err
+	}
+
+	// Protocol-relative URLs ("//cdn.example.com/img.jpg") take the base's scheme, or
+	// https when the base has no web scheme to give, such as a bare host or about:blank
+	if rel.Scheme == "" && rel.Host != "" {
+		rel.Scheme = "https"
+		if base.Scheme == "http" || base.Scheme == "https" {
+			rel.Scheme = base.Scheme
+		}
+		return rel.String(), nil
+	}
+
+	return base.ResolveReference(rel).String(), nil
+}
+
+// StripTrackingParams removes the TrackingParams and TrackingParamPrefixes query parameters from a URL
+func StripTrackingParams(rawURL string) string {
+	return stripQueryParams(rawURL, false)
+}
+
+// StripImageTrackingParams removes tracking and CacheBusterParams query parameters from an
+// image URL, so copies of a CDN image that only differ in them compare equal
+func StripImageTrackingParams(rawURL string) string {
+	return stripQueryParams(rawURL, true)
+}
+
+// stripQueryParams removes tracking query parameters from a URL, and cache busters when
+// cacheBusters is set
+func stripQueryParams(rawURL string, cacheBusters bool) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return rawURL
+	}
+
+	// Walk the raw query to keep the original order and encoding of surviving params
+	var kept []string
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key := pair
+		if idx := strings.Index(pair, "="); idx >= 0 {
+			key = pair[:idx]
+		}
+		if decoded, err := url.QueryUnescape(key); err == nil {
+			key = decoded
+		}
+
+		if isTrackingParam(key) || (cacheBusters && isCacheBusterParam(key)) {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+
+	u.RawQuery = strings.Join(kept, "&")
+	return u.String()
+}
+
+// stripLinkTracking applies StripTrackingParams to the page links a response returns
+func stripLinkTracking(response *models.ScrapeResponse) {
+	if response.NextURL != "" {
+		response.NextURL = StripTrackingParams(response.NextURL)
+	}
+	if response.PrevURL != "" {
+		response.PrevURL = StripTrackingParams(response.PrevURL)
+	}
+	for i, link := range response.ArticleLinks {
+		response.ArticleLinks[i] = StripTrackingParams(link)
+	}
+}
+
+// isTrackingParam checks if a query parameter name is a known tracking parameter
+func isTrackingParam(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, prefix := range TrackingParamPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	for _, param := range TrackingParams {
+		if name == param {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCacheBusterParam checks if a query parameter name is a known cache buster
+func isCacheBusterParam(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, param := range CacheBusterParams {
+		if name == param {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasHostSuffix reports whether host is one of the domains or a subdomain of one
+func hasHostSuffix(host string, domains []string) bool {
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"extract-html-scraper/internal/models"
+)
+
+func TestStripTrackingParams(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"utm params", "https://example.com/a.jpg?utm_source=x&utm_medium=y", "https://example.com/a.jpg"},
+		{"keeps other params", "https://example.com/a.jpg?w=800&utm_campaign=z&fit=crop", "https://example.com/a.jpg?w=800&fit=crop"},
+		{"click ids", "https://example.com/post?fbclid=abc&gclid=def&msclkid=ghi", "https://example.com/post"},
+		{"affiliate ids", "https://example.com/deal?aff_id=1&clickid=2&color=red", "https://example.com/deal?color=red"},
+		{"case insensitive", "https://example.com/post?UTM_Source=x&id=1", "https://example.com/post?id=1"},
+		{"generic names kept", "https://example.com/a.jpg?v=2&ref=home&ts=123", "https://example.com/a.jpg?v=2&ref=home&ts=123"},
+		{"no query", "https://example.com/a.jpg", "https://example.com/a.jpg"},
+		{"keeps encoding", "https://example.com/s?q=a%20b&utm_source=x", "https://example.com/s?q=a%20b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripTrackingParams(tt.in); got != tt.want {
+				t.Errorf("StripTrackingParams(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripImageTrackingParams(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"cache busters", "https://cdn.example.com/a.jpg?v=2&ts=123&cb=9&w=800", "https://cdn.example.com/a.jpg?w=800"},
+		{"tracking and cache busters", "https://cdn.example.com/a.jpg?utm_source=x&_=1700000000", "https://cdn.example.com/a.jpg"},
+		{"generic names kept", "https://cdn.example.com/a.jpg?ref=home&id=4", "https://cdn.example.com/a.jpg?ref=home&id=4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripImageTrackingParams(tt.in); got != tt.want {
+				t.Errorf("StripImageTrackingParams(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripTrackingParamsImageURLs(t *testing.T) {
+	page := `<html><head>
+<meta property="og:image" content="https://cdn.example.com/hero-1200x675.jpg?utm_source=feed&utm_medium=rss">
+</head><body><article><p>Body</p>
+<img src="https://cdn.example.com/photo.jpg?utm_campaign=spring&w=1200&v=3" width="1200" height="800">
+</article></body></html>`
+
+	tests := []struct {
+		name      string
+		strip     bool
+		wantMain  string
+		wantImage string
+	}{
+		{"stripped", true, "https://cdn.example.com/hero-1200x675.jpg", "https://cdn.example.com/photo.jpg?w=1200"},
+		{"kept by default", false, "https://cdn.example.com/hero-1200x675.jpg?utm_source=feed&utm_medium=rss", "https://cdn.example.com/photo.jpg?utm_campaign=spring&w=1200&v=3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultExtractionOptions()
+			options.StripTrackingParams = tt.strip
+			mainImage, images := NewImageExtractorWithOptions(options).ExtractImagesWithMain(parseDoc(t, page), "https://example.com/post")
+
+			if mainImage != tt.wantMain {
+				t.Errorf("main image = %q, want %q", mainImage, tt.wantMain)
+			}
+			found := false
+			for _, image := range images {
+				found = found || image == tt.wantImage
+			}
+			if !found {
+				t.Errorf("images %v missing %q", images, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestStripLinkTracking(t *testing.T) {
+	response := models.ScrapeResponse{
+		NextURL:      "https://example.com/part-2?utm_source=x",
+		PrevURL:      "https://example.com/part-0?fbclid=y&page=0",
+		ArticleLinks: []string{"https://example.com/a?gclid=z", "https://example.com/b"},
+	}
+	stripLinkTracking(&response)
+
+	if response.NextURL != "https://example.com/part-2" {
+		t.Errorf("NextURL = %q", response.NextURL)
+	}
+	if response.PrevURL != "https://example.com/part-0?page=0" {
+		t.Errorf("PrevURL = %q", response.PrevURL)
+	}
+	if want := []string{"https://example.com/a", "https://example.com/b"}; !equalStrings(response.ArticleLinks, want) {
+		t.Errorf("ArticleLinks = %v, want %v", response.ArticleLinks, want)
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		base string
+		want string
+	}{
+		{"protocol-relative on https", "//cdn.example.com/img.jpg", "https://example.com/a", "https://cdn.example.com/img.jpg"},
+		{"protocol-relative on http", "//cdn.example.com/img.jpg", "http://example.com/a", "http://cdn.example.com/img.jpg"},
+		{"protocol-relative without a web base", "//cdn.example.com/img.jpg", "about:blank", "https://cdn.example.com/img.jpg"},
+		{"root-relative", "/media/img.jpg", "https://example.com/news/a", "https://example.com/media/img.jpg"},
+		{"path-relative", "img.jpg", "https://example.com/news/a", "https://example.com/news/img.jpg"},
+		{"absolute kept", "https://other.example.com/img.jpg", "https://example.com/a", "https://other.example.com/img.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveURL(tt.ref, tt.base)
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveURL(%q, %q) = %q, %v, want %q", tt.ref, tt.base, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtocolRelativeImages(t *testing.T) {
+	page := `<html><head>
+<meta property="og:image" content="//cdn.example.com/share-1200x630.jpg">
+</head><body><article>
+<img src="//cdn.example.com/photo.jpg" width="1200" height="800">
+</article></body></html>`
+
+	mainImage, images := NewImageExtractor().ExtractImagesWithMain(parseDoc(t, page), "https://example.com/news/a")
+	if mainImage != "https://cdn.example.com/share-1200x630.jpg" {
+		t.Errorf("main image = %q", mainImage)
+	}
+	for _, image := range images {
+		if !strings.HasPrefix(image, "https://cdn.example.com/") {
+			t.Errorf("image %q not resolved with the page scheme", image)
+		}
+	}
+	if len(images) != 2 {
+		t.Errorf("images = %v, want both", images)
+	}
+}
+
+func TestResolveBaseURL(t *testing.T) {
+	tests := []struct {
+		name string
+		head string
+		want string
+	}{
+		{"no base", "", "https://example.com/blog/post"},
+		{"absolute base", `<base href="https://static.example.com/assets/">`, "https://static.example.com/assets/"},
+		{"relative base", `<base href="/media/">`, "https://example.com/media/"},
+		{"empty base", `<base href=" ">`, "https://example.com/blog/post"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<html><head>"+tt.head+"</head><body></body></html>")
+			if got := ResolveBaseURL(doc, "https://example.com/blog/post"); got != tt.want {
+				t.Errorf("ResolveBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseHrefImages(t *testing.T) {
+	page := `<html><head><base href="https://static.example.com/assets/"></head><body><article>
+<img src="photos/first.jpg" width="1200" height="800">
+<img src="/root/second.jpg" width="1200" height="800">
+</article></body></html>`
+
+	images := NewImageExtractor().ExtractImagesFromHTML(page, "https://example.com/blog/post")
+	want := map[string]bool{
+		"https://static.example.com/assets/photos/first.jpg": true,
+		"https://static.example.com/root/second.jpg":         true,
+	}
+	if len(images) != len(want) {
+		t.Fatalf("images = %v, want %d", images, len(want))
+	}
+	for _, image := range images {
+		if !want[image] {
+			t.Errorf("unexpected image %q", image)
+		}
+	}
+}
+
+func TestResolveBaseURLCanonicalHost(t *testing.T) {
+	tests := []struct {
+		name      string
+		head      string
+		fetchURL  string
+		want      string
+		wantImage string
+	}{
+		{
+			name:      "mobile alternate with www canonical",
+			head:      `<link rel="canonical" href="https://www.example.com/news/transit">`,
+			fetchURL:  "https://m.example.com/news/transit",
+			want:      "https://www.example.com/news/transit",
+			wantImage: "https://www.example.com/media/hero.jpg",
+		},
+		{
+			name:      "amp alternate with bare canonical",
+			head:      `<link rel="canonical" href="https://example.com/news/transit">`,
+			fetchURL:  "https://amp.example.com/news/transit",
+			want:      "https://example.com/news/transit",
+			wantImage: "https://example.com/media/hero.jpg",
+		},
+		{
+			name:      "canonical on another site ignored",
+			head:      `<link rel="canonical" href="https://syndicator.com/news/transit">`,
+			fetchURL:  "https://m.example.com/news/transit",
+			want:      "https://m.example.com/news/transit",
+			wantImage: "https://m.example.com/media/hero.jpg",
+		},
+		{
+			name:      "base href wins",
+			head:      `<base href="https://static.example.com/"><link rel="canonical" href="https://www.example.com/news/transit">`,
+			fetchURL:  "https://m.example.com/news/transit",
+			want:      "https://static.example.com/",
+			wantImage: "https://static.example.com/media/hero.jpg",
+		},
+		{
+			name:      "no canonical",
+			fetchURL:  "https://m.example.com/news/transit",
+			want:      "https://m.example.com/news/transit",
+			wantImage: "https://m.example.com/media/hero.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := `<html><head>` + tt.head + `</head><body><article><img src="/media/hero.jpg" width="1200" height="630"></article></body></html>`
+			if got := ResolveBaseURL(parseDoc(t, page), tt.fetchURL); got != tt.want {
+				t.Errorf("ResolveBaseURL = %q, want %q", got, tt.want)
+			}
+
+			images := NewImageExtractor().ExtractImagesFromHTML(page, tt.fetchURL)
+			if !equalStrings(images, []string{tt.wantImage}) {
+				t.Errorf("images = %q, want %q", images, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestSiteHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"www.example.com", "example.com"},
+		{"m.example.com", "example.com"},
+		{"amp.example.co.uk", "example.co.uk"},
+		{"example.com", "example.com"},
+		{"m.com", "m.com"},
+		{"news.example.com", "news.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := siteHost(tt.host); got != tt.want {
+				t.Errorf("siteHost(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package scraper
+
+import (
+	"regexp"
+	"strings"
+
+	"extract-html-scraper/internal/config"
+	"extract-html-scraper/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type VideoExtractor struct {
+	regexes map[string]*regexp.Regexp
+}
+
+func NewVideoExtractor() *VideoExtractor {
+	return &VideoExtractor{
+		regexes: config.CompileRegexes(),
+	}
+}
+
+// ExtractVideosFromDocument extracts embedded videos from og:video, iframes and <video> elements
+func (ve *VideoExtractor) ExtractVideosFromDocument(doc *goquery.Document, baseURL string) []models.VideoInfo {
+	seen := make(map[string]bool)
+	var videos []models.VideoInfo
+
+	add := func(video *models.VideoInfo) {
+		if video == nil || seen[video.URL] {
+			return
+		}
+		seen[video.URL] = true
+		videos = append(videos, *video)
+	}
+
+	// Open Graph video first, it's usually the primary one
+	for _, property := range []string{OGVideoSecure, OGVideoURL, OGVideo} {
+		if src := FindMetaTag(doc, property, ""); src != "" {
+			add(ve.videoFromURL(src, baseURL, VideoProviderOpenGraph))
+			break
+		}
+	}
+
+	// Embedded players, including lazy-loaded iframes
+	doc.Find("iframe").Each(func(i int, s *goquery.Selection) {
+		src := firstAttr(s, "src", "data-src", "data-lazy-src")
+		if src == "" {
+			return
+		}
+		add(ve.videoFromURL(src, baseURL, ""))
+	})
+
+	// Native video elements
+	doc.Find("video").Each(func(i int, s *goquery.Selection) {
+		src := firstAttr(s, "src", "data-src")
+		if src == "" {
+			src = firstAttr(s.Find("source").First(), "src", "data-src")
+		}
+		if src == "" {
+			return
+		}
+
+		video := ve.videoFromURL(src, baseURL, VideoProviderNative)
+		if video != nil && video.Thumbnail == "" {
+			if poster := firstAttr(s, "poster", "data-poster"); poster != "" {
+				if absPoster, err := ResolveURL(poster, baseURL); err == nil {
+					video.Thumbnail = absPoster
+				}
+			}
+		}
+		add(video)
+	})
+
+	return videos
+}
+
+// videoFromURL builds a VideoInfo, canonicalizing known provider embed URLs.
+// An empty fallbackProvider means unknown URLs are ignored.
+func (ve *VideoExtractor) videoFromURL(src, baseURL, fallbackProvider string) *models.VideoInfo {
+	absURL, err := ResolveURL(src, baseURL)
+	if err != nil {
+		return nil
+	}
+
+	if matches := ve.regexes["youtubeEmbed"].FindStringSubmatch(absURL); len(matches) > 1 {
+		return &models.VideoInfo{
+			URL:       "https://www.youtube.com/watch?v=" + matches[1],
+			Provider:  VideoProviderYouTube,
+			Thumbnail: "https://i.ytimg.com/vi/" + matches[1] + "/hqdefault.jpg",
+		}
+	}
+
+	if matches := ve.regexes["vimeoEmbed"].FindStringSubmatch(absURL); len(matches) > 1 {
+		return &models.VideoInfo{
+			URL:      "https://vimeo.com/" + matches[1],
+			Provider: VideoProviderVimeo,
+		}
+	}
+
+	if fallbackProvider == "" || !strings.HasPrefix(absURL, "http") {
+		return nil
+	}
+
+	return &models.VideoInfo{
+		URL:      absURL,
+		Provider: fallbackProvider,
+	}
+}
@@ -0,0 +1,69 @@
+package scraper
+
+import (
+	"testing"
+
+	"extract-html-scraper/internal/models"
+)
+
+func TestExtractVideosFromDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []models.VideoInfo
+	}{
+		{
+			name: "youtube iframe",
+			body: `<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ?rel=0"></iframe>`,
+			want: []models.VideoInfo{{
+				URL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+				Provider:  VideoProviderYouTube,
+				Thumbnail: "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+			}},
+		},
+		{
+			name: "lazy youtube-nocookie iframe",
+			body: `<iframe data-src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"></iframe>`,
+			want: []models.VideoInfo{{
+				URL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+				Provider:  VideoProviderYouTube,
+				Thumbnail: "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+			}},
+		},
+		{
+			name: "vimeo iframe",
+			body: `<iframe src="https://player.vimeo.com/video/76979871"></iframe>`,
+			want: []models.VideoInfo{{URL: "https://vimeo.com/76979871", Provider: VideoProviderVimeo}},
+		},
+		{
+			name: "native video with source and poster",
+			body: `<video poster="/media/poster.jpg"><source src="/media/clip.mp4" type="video/mp4"></video>`,
+			want: []models.VideoInfo{{
+				URL:       "https://example.com/media/clip.mp4",
+				Provider:  VideoProviderNative,
+				Thumbnail: "https://example.com/media/poster.jpg",
+			}},
+		},
+		{
+			name: "unknown iframe ignored",
+			body: `<iframe src="https://maps.example.com/embed?q=paris"></iframe>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseDoc(t, "<html><body>"+tt.body+"</body></html>")
+			got := NewVideoExtractor().ExtractVideosFromDocument(doc, "https://example.com/post")
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d videos %v, want %v", len(got), got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("video %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}